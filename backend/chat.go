@@ -0,0 +1,526 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrChatNoTarget       = errors.New("no one by that name")
+	ErrChatNoParty        = errors.New("not in a party")
+	ErrChatUnknownCommand = errors.New("unknown command")
+	ErrChatBadCommand     = errors.New("bad command")
+	ErrChatNotModerator   = errors.New("not a moderator")
+)
+
+// ChatMessage
+// A chat message on its way through the server.
+type ChatMessage struct {
+	From *Session
+	Name string
+	ID   string
+	Kind cpnp.ChatKind
+	Text string
+	// Whisper recipient or party name.
+	Target string
+}
+
+// ChatManager
+// Server side chat membership and moderation, things that follow a session between worlds.
+type ChatManager struct {
+	mu      sync.RWMutex
+	parties map[string]map[*Session]struct{}
+	party   map[*Session]string
+
+	// Global and party chat history, world chat is kept by the world.
+	global       *ChatHistory
+	partyHistory map[string]*ChatHistory
+	historySize  int
+
+	// Every message from a player goes through this first.
+	Moderator *ChatModerator
+	mutes     *MuteFilter
+	flood     *FloodFilter
+	// moderators user IDs allowed to /mute and /unmute, see AddModerator.
+	modmu      sync.RWMutex
+	moderators map[uuid.UUID]struct{}
+}
+
+func NewChatManager(config ChatConfig) *ChatManager {
+	c := &ChatManager{
+		parties:    make(map[string]map[*Session]struct{}),
+		party:      make(map[*Session]string),
+		mutes:      NewMuteFilter(),
+		flood:      NewFloodFilter(config.Flood),
+		moderators: make(map[uuid.UUID]struct{}),
+
+		global:       NewChatHistory(config.History),
+		partyHistory: make(map[string]*ChatHistory),
+		historySize:  config.History,
+	}
+	c.Moderator = NewChatModerator(
+		c.mutes,
+		c.flood,
+		MaxLengthFilter(config.MaxLength),
+		StripControlFilter(),
+		WordFilter(config.Words),
+	)
+	return c
+}
+
+// AddModerator
+// Lets a user /mute and /unmute, whatever they are called.
+func (c *ChatManager) AddModerator(uid uuid.UUID) {
+	c.modmu.Lock()
+	defer c.modmu.Unlock()
+	c.moderators[uid] = struct{}{}
+}
+
+// IsModerator
+// If a user is a moderator, by ID so names can't be borrowed.
+func (c *ChatManager) IsModerator(uid uuid.UUID) bool {
+	c.modmu.RLock()
+	defer c.modmu.RUnlock()
+	_, ok := c.moderators[uid]
+	return ok
+}
+
+// Mute
+// Stops a player from chatting for d.
+func (c *ChatManager) Mute(s *Session, d time.Duration) {
+	c.mutes.Mute(s.ID, time.Now().Add(d))
+}
+
+// Unmute
+// Lets a muted player chat again.
+func (c *ChatManager) Unmute(s *Session) {
+	c.mutes.Unmute(s.ID)
+}
+
+// Join
+// Puts a session in a party, leaving any old one.
+func (c *ChatManager) Join(s *Session, party string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.leave(s)
+	members, ok := c.parties[party]
+	if !ok {
+		members = make(map[*Session]struct{})
+		c.parties[party] = members
+	}
+	members[s] = struct{}{}
+	c.party[s] = party
+}
+
+// Leave
+// Takes a session out of its party.
+func (c *ChatManager) Leave(s *Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.leave(s)
+}
+
+// Forget
+// Session is gone for good, drops its flood bucket.
+// Not part of Leave, a bare /join would clear it between messages.
+func (c *ChatManager) Forget(s *Session) {
+	c.flood.Forget(s.ID)
+}
+
+// leave expects c.mu to be held.
+func (c *ChatManager) leave(s *Session) {
+	old, ok := c.party[s]
+	if !ok {
+		return
+	}
+	delete(c.party, s)
+	delete(c.parties[old], s)
+	if len(c.parties[old]) == 0 {
+		delete(c.parties, old)
+		delete(c.partyHistory, old)
+	}
+}
+
+// Party
+// The party a session is in, empty if none.
+func (c *ChatManager) Party(s *Session) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.party[s]
+}
+
+// PartyHistory
+// Chat history of a party, nil if the party doesn't exist.
+func (c *ChatManager) PartyHistory(party string) *ChatHistory {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.parties[party]; !ok {
+		return nil
+	}
+	h, ok := c.partyHistory[party]
+	if !ok {
+		h = NewChatHistory(c.historySize)
+		c.partyHistory[party] = h
+	}
+	return h
+}
+
+// Members
+// Sessions in a party.
+func (c *ChatManager) Members(party string) []*Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	members := make([]*Session, 0, len(c.parties[party]))
+	for s := range c.parties[party] {
+		members = append(members, s)
+	}
+	return members
+}
+
+// parseChatCommand
+// Splits "/cmd args" up, ok is false if it's not a command.
+func parseChatCommand(text string) (cmd, args string, ok bool) {
+	if !strings.HasPrefix(text, "/") {
+		return "", "", false
+	}
+	cmd, args, _ = strings.Cut(text[1:], " ")
+	return strings.ToLower(cmd), strings.TrimSpace(args), true
+}
+
+// splitTarget
+// Pulls the first word off, or a "quoted name" since names have spaces.
+func splitTarget(args string) (target, rest string) {
+	args = strings.TrimSpace(args)
+	if strings.HasPrefix(args, `"`) {
+		end := strings.Index(args[1:], `"`)
+		if end >= 0 {
+			return args[1 : end+1], strings.TrimSpace(args[end+2:])
+		}
+	}
+	target, rest, _ = strings.Cut(args, " ")
+	return target, strings.TrimSpace(rest)
+}
+
+// handleChat
+// Works out what a chat message is and sends it where it needs to go.
+func (w *GameWorld) handleChat(s *Session, kind cpnp.ChatKind, text, target string) {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return
+	}
+
+	msg := ChatMessage{
+		From:   s,
+		Name:   p.Name,
+		ID:     s.ID.String(),
+		Kind:   kind,
+		Text:   text,
+		Target: target,
+	}
+
+	if w.manager != nil {
+		err := w.manager.chat.Moderator.Filter(&msg, time.Now())
+		if err != nil {
+			w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Message rejected: %v", err))
+			return
+		}
+		text = msg.Text
+	}
+
+	if cmd, args, isCmd := parseChatCommand(text); isCmd {
+		err := w.chatCommand(&msg, cmd, args)
+		if err != nil {
+			w.sendSystemChat(s, fmt.Sprintf("/%s: %v", cmd, err))
+		}
+		return
+	}
+
+	err := w.routeChat(msg)
+	if err != nil {
+		w.sendSystemChat(s, err.Error())
+	}
+}
+
+// chatCommand
+// Slash commands, ones that turn into a message get routed.
+func (w *GameWorld) chatCommand(msg *ChatMessage, cmd, args string) error {
+	s := msg.From
+	switch cmd {
+	case "w", "whisper":
+		target, text := splitTarget(args)
+		if target == "" || text == "" {
+			return fmt.Errorf("%w: /w name text", ErrChatBadCommand)
+		}
+		msg.Kind = cpnp.ChatKind_whisper
+		msg.Target = target
+		msg.Text = text
+		return w.routeChat(*msg)
+	case "me":
+		if args == "" {
+			return fmt.Errorf("%w: /me text", ErrChatBadCommand)
+		}
+		msg.Kind = cpnp.ChatKind_emote
+		msg.Text = args
+		return w.routeChat(*msg)
+	case "who":
+		var names []string
+		w.pmu.RLock()
+		for _, p := range w.Players {
+			names = append(names, p.Name)
+		}
+		w.pmu.RUnlock()
+		slices.Sort(names)
+		w.sendSystemChat(s, fmt.Sprintf("%d in %s: %s", len(names), w.Name, strings.Join(names, ", ")))
+		return nil
+	case "where":
+		w.pmu.RLock()
+		p, ok := w.Players[s]
+		w.pmu.RUnlock()
+		if !ok {
+			return nil
+		}
+		p.mu.Lock()
+		x, y := p.X, p.Y
+		p.mu.Unlock()
+		w.sendSystemChat(s, fmt.Sprintf("You are in %s at %d, %d", w.Name, x, y))
+		return nil
+	case "join":
+		if w.manager == nil {
+			return ErrChatNoParty
+		}
+		if args == "" {
+			w.manager.chat.Leave(s)
+			w.sendSystemChat(s, "Left party")
+			return nil
+		}
+		w.manager.chat.Join(s, args)
+		w.sendSystemChat(s, fmt.Sprintf("Joined party %s", args))
+		w.sendChatHistory(s, cpnp.ChatKind_party, 0, w.config.Chat.HistoryJoin)
+		return nil
+	case "mute", "unmute":
+		return w.moderateCommand(msg, cmd, args)
+	}
+	return ErrChatUnknownCommand
+}
+
+// defaultMute how long /mute lasts without minutes given.
+const defaultMute = 5 * time.Minute
+
+// moderateCommand
+// /mute name [minutes] and /unmute name, moderators only.
+func (w *GameWorld) moderateCommand(msg *ChatMessage, cmd, args string) error {
+	if w.manager == nil || msg.From == nil || !w.manager.chat.IsModerator(msg.From.ID) {
+		return ErrChatNotModerator
+	}
+	name, rest := splitTarget(args)
+	if name == "" {
+		return fmt.Errorf("%w: /%s name", ErrChatBadCommand, cmd)
+	}
+	target, pname := w.findSession(name)
+	if target == nil {
+		return fmt.Errorf("%w: %s", ErrChatNoTarget, name)
+	}
+
+	if cmd == "unmute" {
+		w.manager.chat.Unmute(target)
+		w.sendSystemChat(msg.From, fmt.Sprintf("Unmuted %s", pname))
+		return nil
+	}
+
+	d := defaultMute
+	if rest != "" {
+		minutes, err := strconv.Atoi(rest)
+		if err != nil || minutes <= 0 {
+			return fmt.Errorf("%w: /mute name [minutes]", ErrChatBadCommand)
+		}
+		d = time.Duration(minutes) * time.Minute
+	}
+	w.manager.chat.Mute(target, d)
+	w.sendSystemChat(msg.From, fmt.Sprintf("Muted %s for %s", pname, d))
+	if tw := w.manager.WorldOf(target); tw != nil {
+		tw.sendNotice(target, cpnp.NoticeKind_warning, fmt.Sprintf("You have been muted for %s", d))
+	}
+	return nil
+}
+
+// routeChat
+// Sends a message to whoever should get it based on the kind.
+func (w *GameWorld) routeChat(msg ChatMessage) error {
+	var to []*Session
+	switch msg.Kind {
+	case cpnp.ChatKind_global:
+		if w.manager == nil {
+			to = w.sessions()
+			break
+		}
+		for _, name := range w.manager.Names() {
+			if world, ok := w.manager.Get(name); ok {
+				to = append(to, world.sessions()...)
+				to = append(to, world.spectatorSessions()...)
+			}
+		}
+	case cpnp.ChatKind_proximity:
+		to = w.sessionsNear(msg.From, w.config.ChatProximity)
+	case cpnp.ChatKind_party:
+		if w.manager == nil {
+			return ErrChatNoParty
+		}
+		msg.Target = w.manager.chat.Party(msg.From)
+		if msg.Target == "" {
+			return ErrChatNoParty
+		}
+		to = w.manager.chat.Members(msg.Target)
+	case cpnp.ChatKind_whisper:
+		target, name := w.findSession(msg.Target)
+		if target == nil {
+			return fmt.Errorf("%w: %s", ErrChatNoTarget, msg.Target)
+		}
+		msg.Target = name
+		to = []*Session{target}
+		if target != msg.From {
+			to = append(to, msg.From)
+		}
+	case cpnp.ChatKind_world, cpnp.ChatKind_emote:
+		to = append(w.sessions(), w.spectatorSessions()...)
+	default:
+		// Clients can't send system messages.
+		msg.Kind = cpnp.ChatKind_world
+		to = append(w.sessions(), w.spectatorSessions()...)
+	}
+	w.sendChat(to, msg)
+	e := Event{Kind: EventChat, ID: msg.ID, Name: msg.Name, Text: msg.Text, Channel: msg.Kind.String(), Target: msg.Target}
+	if msg.Kind == cpnp.ChatKind_whisper {
+		// Who whispered to who, never what.
+		e.Text = ""
+	}
+	w.record(e)
+	if h := w.chatHistory(msg.From, msg.Kind); h != nil {
+		h.Add(msg, time.Now())
+	}
+	return nil
+}
+
+// sessions
+// Everyone in the world.
+func (w *GameWorld) sessions() []*Session {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	to := make([]*Session, 0, len(w.Players))
+	for s := range w.Players {
+		to = append(to, s)
+	}
+	return to
+}
+
+// sessionsNear
+// Everyone within radius cells of a session, including itself.
+func (w *GameWorld) sessionsNear(s *Session, radius int) []*Session {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	p, ok := w.Players[s]
+	if !ok {
+		return nil
+	}
+	p.mu.Lock()
+	cx, cy := p.X, p.Y
+	p.mu.Unlock()
+
+	var to []*Session
+	for other, op := range w.Players {
+		op.mu.Lock()
+		d := w.Map.Distance(cx, cy, op.X, op.Y)
+		op.mu.Unlock()
+		if d <= radius {
+			to = append(to, other)
+		}
+	}
+	return to
+}
+
+// findSession
+// Looks up a player by ID or name, in any world.
+func (w *GameWorld) findSession(who string) (*Session, string) {
+	if w.manager == nil {
+		return w.findLocalSession(who)
+	}
+	for _, name := range w.manager.Names() {
+		world, ok := w.manager.Get(name)
+		if !ok {
+			continue
+		}
+		if s, pname := world.findLocalSession(who); s != nil {
+			return s, pname
+		}
+	}
+	return nil, ""
+}
+
+func (w *GameWorld) findLocalSession(who string) (*Session, string) {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	for s, p := range w.Players {
+		if s.ID.String() == who || strings.EqualFold(p.Name, who) {
+			return s, p.Name
+		}
+	}
+	return nil, ""
+}
+
+func (w *GameWorld) sendSystemChat(s *Session, text string) {
+	w.sendChat([]*Session{s}, ChatMessage{Kind: cpnp.ChatKind_system, Text: text})
+}
+
+// sendChat
+// Sends a chat message to a list of sessions.
+func (w *GameWorld) sendChat(to []*Session, chat ChatMessage) {
+	if len(to) == 0 {
+		return
+	}
+
+	w.writer.mu.Lock()
+	defer w.writer.mu.Unlock()
+
+	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastChat)
+	if err != nil {
+		log.Printf("Error creating chat packet: %v", err)
+		return
+	}
+
+	err = msg.SetText(chat.Text)
+	if err != nil {
+		log.Printf("Error creating chat packet: %v", err)
+		return
+	}
+	err = msg.SetName(chat.Name)
+	if err != nil {
+		log.Printf("Error creating chat packet: %v", err)
+		return
+	}
+	err = msg.SetId(chat.ID)
+	if err != nil {
+		log.Printf("Error creating chat packet: %v", err)
+		return
+	}
+	err = msg.SetTarget(chat.Target)
+	if err != nil {
+		log.Printf("Error creating chat packet: %v", err)
+		return
+	}
+	msg.SetKind(chat.Kind)
+
+	for _, s := range to {
+		_, _ = s.Send(w.writer, msg.Message(), OpCodeBChat)
+	}
+}
@@ -0,0 +1,218 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var (
+	ErrChatTooLong     = errors.New("message too long")
+	ErrChatInvalidUTF8 = errors.New("message not valid UTF-8")
+	ErrChatEmpty       = errors.New("message empty")
+	ErrChatFlood       = errors.New("sending messages too fast")
+	ErrChatMuted       = errors.New("muted")
+)
+
+// ChatFilter
+// One step of chat moderation, can change the message or reject it.
+type ChatFilter interface {
+	Filter(msg *ChatMessage, now time.Time) error
+}
+
+// ChatFilterFunc
+// Plain function as a ChatFilter.
+type ChatFilterFunc func(msg *ChatMessage, now time.Time) error
+
+func (f ChatFilterFunc) Filter(msg *ChatMessage, now time.Time) error {
+	return f(msg, now)
+}
+
+// ChatModerator
+// Runs filters in order, the first error rejects the message.
+type ChatModerator struct {
+	filters []ChatFilter
+}
+
+func NewChatModerator(filters ...ChatFilter) *ChatModerator {
+	return &ChatModerator{filters: filters}
+}
+
+// Add
+// Adds filters to the end of the pipeline.
+func (m *ChatModerator) Add(filters ...ChatFilter) {
+	m.filters = append(m.filters, filters...)
+}
+
+func (m *ChatModerator) Filter(msg *ChatMessage, now time.Time) error {
+	for _, f := range m.filters {
+		err := f.Filter(msg, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxLengthFilter
+// Rejects invalid UTF-8 and anything over n characters.
+func MaxLengthFilter(n int) ChatFilter {
+	return ChatFilterFunc(func(msg *ChatMessage, _ time.Time) error {
+		if !utf8.ValidString(msg.Text) {
+			return ErrChatInvalidUTF8
+		}
+		if n > 0 && utf8.RuneCountInString(msg.Text) > n {
+			return fmt.Errorf("%w: max %d", ErrChatTooLong, n)
+		}
+		return nil
+	})
+}
+
+// StripControlFilter
+// Removes control characters, rejects what ends up empty.
+func StripControlFilter() ChatFilter {
+	return ChatFilterFunc(func(msg *ChatMessage, _ time.Time) error {
+		msg.Text = strings.TrimSpace(strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, msg.Text))
+		if msg.Text == "" {
+			return ErrChatEmpty
+		}
+		return nil
+	})
+}
+
+// WordFilter
+// Masks whole words from the list with *, ignoring case.
+func WordFilter(words []string) ChatFilter {
+	var quoted []string
+	for _, w := range words {
+		w = strings.TrimSpace(w)
+		if w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	if len(quoted) == 0 {
+		return ChatFilterFunc(func(*ChatMessage, time.Time) error { return nil })
+	}
+	re := regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	return ChatFilterFunc(func(msg *ChatMessage, _ time.Time) error {
+		msg.Text = re.ReplaceAllStringFunc(msg.Text, func(s string) string {
+			return strings.Repeat("*", utf8.RuneCountInString(s))
+		})
+		return nil
+	})
+}
+
+// FloodFilter
+// Per player message rate limit.
+type FloodFilter struct {
+	limit RateLimit
+
+	mu      sync.Mutex
+	buckets map[uuid.UUID]*tokenBucket
+}
+
+func NewFloodFilter(limit RateLimit) *FloodFilter {
+	return &FloodFilter{
+		limit:   limit,
+		buckets: make(map[uuid.UUID]*tokenBucket),
+	}
+}
+
+func (f *FloodFilter) Filter(msg *ChatMessage, now time.Time) error {
+	if msg.From == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b, ok := f.buckets[msg.From.ID]
+	if !ok {
+		nb := newTokenBucket(f.limit)
+		b = &nb
+		f.buckets[msg.From.ID] = b
+	}
+	if !b.Allow(now) {
+		return ErrChatFlood
+	}
+	return nil
+}
+
+// Forget
+// Drops the bucket for a player that left.
+func (f *FloodFilter) Forget(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.buckets, id)
+}
+
+// MuteFilter
+// Timed mutes applied by moderators.
+// Muted players can still use commands that don't say anything.
+type MuteFilter struct {
+	mu    sync.Mutex
+	until map[uuid.UUID]time.Time
+}
+
+func NewMuteFilter() *MuteFilter {
+	return &MuteFilter{until: make(map[uuid.UUID]time.Time)}
+}
+
+// Mute
+// Mutes a player until the given time.
+func (f *MuteFilter) Mute(id uuid.UUID, until time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.until[id] = until
+}
+
+// Unmute
+// Lifts a mute early.
+func (f *MuteFilter) Unmute(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.until, id)
+}
+
+// Muted
+// When a mute ends, ok is false if not muted.
+func (f *MuteFilter) Muted(id uuid.UUID, now time.Time) (time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	until, ok := f.until[id]
+	if !ok {
+		return time.Time{}, false
+	}
+	if !now.Before(until) {
+		delete(f.until, id)
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+func (f *MuteFilter) Filter(msg *ChatMessage, now time.Time) error {
+	if msg.From == nil {
+		return nil
+	}
+	if cmd, _, ok := parseChatCommand(msg.Text); ok {
+		switch cmd {
+		case "who", "where", "join":
+			return nil
+		}
+	}
+	until, muted := f.Muted(msg.From.ID, now)
+	if !muted {
+		return nil
+	}
+	return fmt.Errorf("%w for %s", ErrChatMuted, until.Sub(now).Round(time.Second))
+}
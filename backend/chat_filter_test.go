@@ -0,0 +1,71 @@
+package backend
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestChatModerator(t *testing.T) {
+	m := NewChatModerator(MaxLengthFilter(12), StripControlFilter(), WordFilter([]string{"darn"}))
+	now := time.Now()
+
+	msg := ChatMessage{Text: "oh DARN\x07 it"}
+	if err := m.Filter(&msg, now); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Text != "oh **** it" {
+		t.Errorf("got %q", msg.Text)
+	}
+
+	msg = ChatMessage{Text: "darning"}
+	_ = m.Filter(&msg, now)
+	if msg.Text != "darning" {
+		t.Errorf("masked part of a word %q", msg.Text)
+	}
+
+	msg = ChatMessage{Text: strings.Repeat("é", 13)}
+	if err := m.Filter(&msg, now); !errors.Is(err, ErrChatTooLong) {
+		t.Errorf("expected too long, got %v", err)
+	}
+	msg = ChatMessage{Text: "\xff"}
+	if err := m.Filter(&msg, now); !errors.Is(err, ErrChatInvalidUTF8) {
+		t.Errorf("expected invalid, got %v", err)
+	}
+	msg = ChatMessage{Text: "\n\t"}
+	if err := m.Filter(&msg, now); !errors.Is(err, ErrChatEmpty) {
+		t.Errorf("expected empty, got %v", err)
+	}
+}
+
+func TestChatFloodAndMute(t *testing.T) {
+	s := &Session{ID: uuid.Must(uuid.NewV4())}
+	flood := NewFloodFilter(RateLimit{Rate: 1, Burst: 2})
+	mutes := NewMuteFilter()
+	m := NewChatModerator(mutes, flood)
+	now := time.Now()
+
+	for i := range 2 {
+		if err := m.Filter(&ChatMessage{From: s, Text: "hi"}, now); err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+	}
+	if err := m.Filter(&ChatMessage{From: s, Text: "hi"}, now); !errors.Is(err, ErrChatFlood) {
+		t.Errorf("expected flood, got %v", err)
+	}
+
+	mutes.Mute(s.ID, now.Add(time.Minute))
+	later := now.Add(2 * time.Second)
+	if err := m.Filter(&ChatMessage{From: s, Text: "hi"}, later); !errors.Is(err, ErrChatMuted) {
+		t.Errorf("expected muted, got %v", err)
+	}
+	if err := m.Filter(&ChatMessage{From: s, Text: "/who"}, later); err != nil {
+		t.Errorf("muted player can't /who: %v", err)
+	}
+	if err := m.Filter(&ChatMessage{From: s, Text: "hi"}, now.Add(2*time.Minute)); err != nil {
+		t.Errorf("mute didn't expire: %v", err)
+	}
+}
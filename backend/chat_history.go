@@ -0,0 +1,183 @@
+package backend
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+// ChatEntry
+// A chat message the server remembered.
+type ChatEntry struct {
+	ChatMessage
+	// Seq position in the channel, starts at 1.
+	Seq  uint64
+	Time time.Time
+}
+
+// ChatHistory
+// Ring of the most recent messages in one channel.
+type ChatHistory struct {
+	mu      sync.RWMutex
+	entries []ChatEntry
+	next    int
+	size    int
+	seq     uint64
+}
+
+func NewChatHistory(n int) *ChatHistory {
+	return &ChatHistory{entries: make([]ChatEntry, max(n, 1))}
+}
+
+// Add
+// Remembers a message, pushing out the oldest once full.
+func (h *ChatHistory) Add(msg ChatMessage, now time.Time) ChatEntry {
+	// Don't hold onto the session.
+	msg.From = nil
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq++
+	e := ChatEntry{ChatMessage: msg, Seq: h.seq, Time: now}
+	h.entries[h.next] = e
+	h.next = (h.next + 1) % len(h.entries)
+	h.size = min(h.size+1, len(h.entries))
+	return e
+}
+
+// restore
+// Puts back a saved entry keeping its seq, oldest first.
+func (h *ChatHistory) restore(e ChatEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seq = max(h.seq, e.Seq)
+	h.entries[h.next] = e
+	h.next = (h.next + 1) % len(h.entries)
+	h.size = min(h.size+1, len(h.entries))
+}
+
+// Len
+// How many messages are remembered.
+func (h *ChatHistory) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.size
+}
+
+// Page
+// Up to count messages older than before, oldest first.
+// before 0 is the newest. more is true if there are older ones still.
+func (h *ChatHistory) Page(before uint64, count int) ([]ChatEntry, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	// Oldest is size back from next.
+	start := (h.next - h.size + len(h.entries)) % len(h.entries)
+	end := h.size
+	for end > 0 && before != 0 && h.entries[(start+end-1)%len(h.entries)].Seq >= before {
+		end--
+	}
+	first := max(end-count, 0)
+
+	page := make([]ChatEntry, 0, end-first)
+	for i := first; i < end; i++ {
+		page = append(page, h.entries[(start+i)%len(h.entries)])
+	}
+	return page, first > 0
+}
+
+// historyChannel
+// The kind history is kept under, emotes go with world chat.
+// Proximity and whispers aren't kept.
+func historyChannel(kind cpnp.ChatKind) (cpnp.ChatKind, bool) {
+	switch kind {
+	case cpnp.ChatKind_world, cpnp.ChatKind_emote:
+		return cpnp.ChatKind_world, true
+	case cpnp.ChatKind_global, cpnp.ChatKind_party:
+		return kind, true
+	}
+	return kind, false
+}
+
+// chatHistory
+// History for a channel as a session sees it, nil if there is none.
+func (w *GameWorld) chatHistory(s *Session, kind cpnp.ChatKind) *ChatHistory {
+	kind, ok := historyChannel(kind)
+	if !ok {
+		return nil
+	}
+	if kind == cpnp.ChatKind_world {
+		return w.history
+	}
+	if w.manager == nil {
+		return nil
+	}
+	if kind == cpnp.ChatKind_global {
+		return w.manager.chat.global
+	}
+	return w.manager.chat.PartyHistory(w.manager.chat.Party(s))
+}
+
+// sendChatBacklog
+// The recent messages from every channel the session can see.
+func (w *GameWorld) sendChatBacklog(s *Session) {
+	n := w.config.Chat.HistoryJoin
+	if n <= 0 {
+		return
+	}
+	for _, kind := range []cpnp.ChatKind{cpnp.ChatKind_global, cpnp.ChatKind_world, cpnp.ChatKind_party} {
+		h := w.chatHistory(s, kind)
+		if h == nil || h.Len() == 0 {
+			continue
+		}
+		w.sendChatHistory(s, kind, 0, n)
+	}
+}
+
+// sendChatHistory
+// A page of history, sent even if it's empty so the client knows.
+func (w *GameWorld) sendChatHistory(s *Session, kind cpnp.ChatKind, before uint64, count int) {
+	kind, _ = historyChannel(kind)
+	var entries []ChatEntry
+	var more bool
+	if h := w.chatHistory(s, kind); h != nil {
+		entries, more = h.Page(before, count)
+	}
+
+	err := QueueMessage(s, OpCodeSChatHistory, cpnp.NewRootGameServerChatHistory, func(msg cpnp.GameServerChatHistory) error {
+		msg.SetKind(kind)
+		msg.SetMore(more)
+		list, err := msg.NewEntries(int32(len(entries)))
+		if err != nil {
+			return err
+		}
+		for i, e := range entries {
+			item := list.At(i)
+			item.SetSeq(e.Seq)
+			item.SetTime(e.Time.UnixMilli())
+			item.SetKind(e.Kind)
+			err = item.SetName(e.Name)
+			if err != nil {
+				return err
+			}
+			err = item.SetId(e.ID)
+			if err != nil {
+				return err
+			}
+			err = item.SetText(e.Text)
+			if err != nil {
+				return err
+			}
+			err = item.SetTarget(e.Target)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error sending chat history packet: %v\n", err)
+	}
+}
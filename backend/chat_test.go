@@ -0,0 +1,184 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+func TestParseChatCommand(t *testing.T) {
+	cmd, args, ok := parseChatCommand("/W  bob hello there")
+	if !ok || cmd != "w" || args != "bob hello there" {
+		t.Errorf("got %q %q %v", cmd, args, ok)
+	}
+	if _, _, ok = parseChatCommand("hello /w"); ok {
+		t.Error("not a command")
+	}
+
+	target, rest := splitTarget(args)
+	if target != "bob" || rest != "hello there" {
+		t.Errorf("got %q %q", target, rest)
+	}
+	target, rest = splitTarget(`"Mr. Bob Smith" hi`)
+	if target != "Mr. Bob Smith" || rest != "hi" {
+		t.Errorf("quoted got %q %q", target, rest)
+	}
+}
+
+func TestChatParty(t *testing.T) {
+	chat := NewChatManager(ChatConfig{})
+	a := new(Session)
+	b := new(Session)
+
+	chat.Join(a, "red")
+	chat.Join(b, "red")
+	if n := len(chat.Members("red")); n != 2 {
+		t.Fatalf("members %d", n)
+	}
+
+	chat.Join(a, "blue")
+	if chat.Party(a) != "blue" || len(chat.Members("red")) != 1 {
+		t.Error("join didn't leave old party")
+	}
+
+	chat.Leave(b)
+	if _, ok := chat.parties["red"]; ok {
+		t.Error("empty party not removed")
+	}
+}
+
+func TestChatHistoryPage(t *testing.T) {
+	h := NewChatHistory(5)
+	now := time.Now()
+	for i := range 8 {
+		h.Add(ChatMessage{From: new(Session), Text: strconv.Itoa(i)}, now)
+	}
+	if h.Len() != 5 {
+		t.Fatalf("len %d", h.Len())
+	}
+
+	page, more := h.Page(0, 2)
+	if len(page) != 2 || page[0].Seq != 7 || page[1].Seq != 8 || !more {
+		t.Fatalf("newest page %+v more %v", page, more)
+	}
+	if page[0].From != nil {
+		t.Error("history kept the session")
+	}
+
+	page, more = h.Page(page[0].Seq, 10)
+	if len(page) != 3 || page[0].Text != "3" || page[2].Seq != 6 || more {
+		t.Fatalf("older page %+v more %v", page, more)
+	}
+
+	page, _ = h.Page(4, 10)
+	if len(page) != 0 {
+		t.Errorf("nothing kept before 4, got %d", len(page))
+	}
+}
+
+func TestChatModerators(t *testing.T) {
+	secrets := filepath.Join(t.TempDir(), "reserved.secrets")
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Chat.Moderators = []string{"Mod Person"}
+		c.ReservedSecrets = secrets
+	})
+	uid, err := g.db.GetUser("mod person")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !g.worlds.chat.IsModerator(uid) {
+		t.Fatal("moderator not added by ID")
+	}
+	if _, _, err := g.db.LoginUser("MOD PERSON", ""); !errors.Is(err, ErrLoginSecret) {
+		t.Errorf("logged in as the moderator without a secret: %v", err)
+	}
+	if info, err := os.Stat(secrets); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("secrets file %v %v", info, err)
+	}
+	data, _ := os.ReadFile(secrets)
+	reserved, secret, _ := strings.Cut(strings.TrimSpace(string(data)), "\t")
+	if _, _, err := g.db.LoginUser(reserved, secret); err != nil {
+		t.Errorf("saved secret didn't log in: %v", err)
+	}
+
+	player := g.join(t, "")
+	name, _ := g.db.GetUserByID(player.ID)
+	if err := g.worlds.Rename(player, "Mod  Person"); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("renamed to the moderator: %v", err)
+	}
+	if err := g.w.chatCommand(&ChatMessage{From: player, Name: "Mod Person"}, "mute", name); !errors.Is(err, ErrChatNotModerator) {
+		t.Errorf("player used /mute: %v", err)
+	}
+
+	mod := testStart(g.sessions.CreateSession(uid, "127.0.0.1", nil))
+	if err := g.worlds.Connect(mod, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.w.chatCommand(&ChatMessage{From: mod, Name: "Mod Person"}, "mute", `"`+name+`"`); err != nil {
+		t.Errorf("moderator /mute: %v", err)
+	}
+}
+
+func TestChatWhisperLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	g := newTestGame(t, func(c *GameConfig) {
+		c.EventLog = path
+	})
+	from := g.join(t, "")
+	to := g.join(t, "")
+	name, _ := g.db.GetUserByID(to.ID)
+
+	err := g.w.routeChat(ChatMessage{From: from, Kind: cpnp.ChatKind_whisper, Target: name, Text: "secret plans"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = g.w.routeChat(ChatMessage{From: from, Kind: cpnp.ChatKind_world, Text: "hello all"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.worlds.Shutdown()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret plans")) {
+		t.Error("whisper text in the event log")
+	}
+	state, err := Replay(bytes.NewReader(data), DefaultWorldName, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Chat) != 2 || state.Chat[0].Target != name || state.Chat[1].Text != "hello all" {
+		t.Errorf("chat events %+v", state.Chat)
+	}
+}
+
+func TestChatFloodJoin(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Chat.Flood = RateLimit{Rate: 0.01, Burst: 2}
+	})
+	s := g.join(t, "")
+	chat := g.worlds.chat
+	now := time.Now()
+
+	for range 2 {
+		if err := chat.Moderator.Filter(&ChatMessage{From: s, Text: "spam"}, now); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Leaving a party doesn't hand out a new bucket.
+	if err := g.w.chatCommand(&ChatMessage{From: s}, "join", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := chat.Moderator.Filter(&ChatMessage{From: s, Text: "spam"}, now); !errors.Is(err, ErrChatFlood) {
+		t.Errorf("expected flood after /join, got %v", err)
+	}
+}
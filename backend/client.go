@@ -2,14 +2,16 @@ package backend
 
 import (
 	"context"
-	"crypto/sha1"
 	"crypto/tls"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -26,6 +28,8 @@ type ClientPacketHandlerFunc func([]byte)
 
 type Client struct {
 	Name string
+	// World the server says we are in.
+	World string
 
 	Sess   *webtransport.Session
 	Stream *webtransport.Stream
@@ -37,15 +41,53 @@ type Client struct {
 	reader *PacketReader
 
 	// Close chan
-	Closing chan struct{}
+	Closing   chan struct{}
+	closeOnce sync.Once
+	state     atomic.Int32
 
-	garbageWait   atomic.Bool
+	// spectator logged in with ClientConnection.Spectate.
+	spectator bool
+	// conn how we connected, for reconnecting.
+	conn ClientConnection
+
+	garbageStats  ClientGarbageStats
+	garbageStatMu sync.Mutex
+
+	// garbage the current challenge, swapped whole by the packet loop.
+	garbage       atomic.Pointer[clientChallenge]
 	garbageTicker *time.Ticker
-	garbageAmount int
-	garbageBase   []byte
+	// garbageNonce last work mode nonce for garbageNonceOf, only used by runGarbage.
+	garbageNonce   uint64
+	garbageNonceOf *clientChallenge
 
 	lastRec  atomic.Int64
 	lastSent atomic.Int64
+
+	mover clientMover
+	// mirror who is in our world, see Mirror.
+	mirror WorldMirror
+	events clientEvents
+
+	// Walking a MoveTo path.
+	pathing atomic.Bool
+}
+
+// clientChallenge
+// A garbage challenge from the server, only the counters change once stored.
+type clientChallenge struct {
+	id       uint32
+	batches  uint32
+	window   uint32
+	amount   int
+	base     []byte
+	hash     cpnp.GarbageHash
+	encoding cpnp.GarbageEncoding
+	mode     cpnp.GarbageMode
+	diff     int
+
+	// Batches sent and acked, a new challenge starts its own.
+	sent  atomic.Uint32
+	acked atomic.Uint32
 }
 
 // ClientConnection
@@ -55,10 +97,31 @@ type Client struct {
 //
 // Defaults to localhost:8770 and localhost:8771
 type ClientConnection struct {
-	Name     string
+	Name string
+	// Secret from logging in as Name before, empty for a new name.
+	// Filled in when the server makes a new user, see Client.Secret.
+	Secret   string
 	IP       string
 	HTTPPort string
 	WTPort   string
+
+	// World to join, empty for the server default.
+	World string
+
+	// Spectate watch without a player, Follow who to watch.
+	Spectate bool
+	Follow   string
+
+	// Latency simulated delay added to outgoing moves.
+	Latency time.Duration
+
+	// GarbageHashes, GarbageEncodings and GarbageModes to tell the server we support, empty for all of them.
+	GarbageHashes    []cpnp.GarbageHash
+	GarbageEncodings []cpnp.GarbageEncoding
+	GarbageModes     []cpnp.GarbageMode
+
+	// Reconnect what to do when the connection drops, the zero value closes.
+	Reconnect ReconnectPolicy
 }
 
 // ClientConnect
@@ -76,7 +139,54 @@ func ClientConnect(cc ClientConnection) (*Client, error) {
 	if cc.IP == "" {
 		cc.IP = "127.0.0.1"
 	}
+	if len(cc.GarbageHashes) == 0 {
+		cc.GarbageHashes = GarbageHashes
+	}
+	if len(cc.GarbageEncodings) == 0 {
+		cc.GarbageEncodings = GarbageEncodings
+	}
+	if len(cc.GarbageModes) == 0 {
+		cc.GarbageModes = GarbageModes
+	}
+
+	ses, err := dialClient(&cc)
+	if err != nil {
+		return nil, err
+	}
+
+	client := newClient(cc)
+	client.Sess = ses
+
+	go client.HandleStream()
+	go client.Run()
+
+	return client, nil
+}
+
+// Secret
+// Login secret for our name, keep it to log in as it again.
+func (c *Client) Secret() string {
+	return c.conn.Secret
+}
+
+// dialClient
+// Logs in over http then opens the WebTransport session with the code.
+// Logging in as someone whose session dropped resumes that session.
+// Keeps the secret the server hands out for a new name in cc.
+func dialClient(cc *ClientConnection) (*webtransport.Session, error) {
 	conS := fmt.Sprintf("http://%s:%s/login?name=%s", cc.IP, cc.HTTPPort, url.QueryEscape(cc.Name))
+	if cc.Secret != "" {
+		conS += "&secret=" + url.QueryEscape(cc.Secret)
+	}
+	if cc.World != "" {
+		conS += "&world=" + url.QueryEscape(cc.World)
+	}
+	if cc.Spectate {
+		conS += "&spectate=1&follow=" + url.QueryEscape(cc.Follow)
+	}
+	conS += "&hashes=" + url.QueryEscape(joinNames(cc.GarbageHashes))
+	conS += "&encodings=" + url.QueryEscape(joinNames(cc.GarbageEncodings))
+	conS += "&modes=" + url.QueryEscape(joinNames(cc.GarbageModes))
 	loginRes, err := http.Get(conS)
 	if err != nil {
 		return nil, err
@@ -87,19 +197,21 @@ func ClientConnect(cc ClientConnection) (*Client, error) {
 		return nil, err
 	}
 
+	if loginRes.StatusCode == http.StatusForbidden {
+		return nil, fmt.Errorf("%w: %w", ErrClientLogin, ErrClientSecret)
+	}
 	if loginRes.StatusCode != http.StatusOK {
-		log.Printf("Client Login error: %s", loginRes.Status)
-		return nil, errors.New(loginRes.Status)
+		return nil, fmt.Errorf("%w: %s", ErrClientLogin, loginRes.Status)
+	}
+	if secret := loginRes.Header.Get(LoginSecretHeader); secret != "" {
+		cc.Secret = secret
 	}
-
-	// log.Println("Code: ", string(login))
 
 	var headers http.Header
 	var d webtransport.Dialer
 	d.QUICConfig = &quic.Config{
 		EnableDatagrams: true,
 	}
-	// d.QUICConfig.EnableDatagrams = true
 	d.TLSClientConfig = &tls.Config{
 		InsecureSkipVerify: true,
 	}
@@ -108,50 +220,58 @@ func ClientConnect(cc ClientConnection) (*Client, error) {
 	if err != nil {
 		if rsp != nil {
 			_ = rsp.Body.Close()
+			if rsp.StatusCode == http.StatusConflict {
+				return nil, fmt.Errorf("%w: %w", ErrClientLogin, ErrClientConflict)
+			}
 		}
-		log.Fatal(err)
+		return nil, fmt.Errorf("dial: %w", err)
 	}
-	// log.Println("Status", rsp.StatusCode)
 	if rsp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("login error: %v", loginRes.Status)
+		return nil, fmt.Errorf("%w: %s", ErrClientLogin, rsp.Status)
 	}
+	return ses, nil
+}
 
+// newClient
+// A client that isn't connected to anything yet.
+func newClient(cc ClientConnection) *Client {
 	gtick := time.NewTicker(time.Second)
 	gtick.Stop()
 	client := &Client{
 		Name:          cc.Name,
-		Sess:          ses,
 		handlers:      make(map[uint16]ClientPacketHandlerFunc),
 		writer:        NewPacketWriter(),
 		reader:        NewPacketReader(),
 		incoming:      make(chan Packet, 1024),
 		garbageTicker: gtick,
 		Closing:       make(chan struct{}),
+		spectator:     cc.Spectate,
+		conn:          cc,
 	}
 
-	client.garbageWait.Store(false)
+	client.mover.latency = cc.Latency
+	client.mover.queue = make(chan queuedMove, 1024)
 
 	client.setupHandlers()
-
-	go client.HandleStream()
-	go client.Run()
-
-	return client, nil
+	return client
 }
 
 // HandleStream
 // Accepts a stream from the server and starts reading from it.
+// When it ends the client reconnects or closes, see ReconnectPolicy.
 func (c *Client) HandleStream() {
-	stream, err := c.Sess.AcceptStream(context.Background())
+	c.writer.mu.Lock()
+	ses := c.Sess
+	c.writer.mu.Unlock()
+	stream, err := ses.AcceptStream(context.Background())
 	if err != nil {
-		log.Printf("Error accepting stream: %v\n", err)
-		return
-	}
-	if stream == nil {
-		log.Printf("Stream is nil\n")
+		c.dropped(fmt.Errorf("accepting stream: %w", err))
 		return
 	}
+	c.writer.mu.Lock()
 	c.Stream = stream
+	c.writer.mu.Unlock()
+	c.setState(ClientConnected, nil)
 
 	err = HandleStream(stream, c.incoming, c.Closing)
 	if err != nil {
@@ -159,8 +279,10 @@ func (c *Client) HandleStream() {
 		rcv := time.Since(time.Unix(0, c.lastRec.Load())).String()
 		log.Printf("Client stream: %v (Sent last: %s, Recv Last: %s)\n", err, snt, rcv)
 	}
+	c.writer.mu.Lock()
 	c.Stream = nil
-	c.Close()
+	c.writer.mu.Unlock()
+	c.dropped(err)
 }
 
 func (c *Client) AddHandler(opcode uint16, handler ClientPacketHandlerFunc) {
@@ -169,6 +291,7 @@ func (c *Client) AddHandler(opcode uint16, handler ClientPacketHandlerFunc) {
 
 func (c *Client) Run() {
 	go c.runGarbage()
+	go c.runMoves()
 	for {
 		select {
 		case <-c.Closing:
@@ -187,11 +310,36 @@ func (c *Client) Run() {
 	}
 }
 
+// Close
+// Stops the client, safe to call more than once.
 func (c *Client) Close() {
-	if c.Closing != nil {
+	c.closeWith(nil)
+}
+
+// closeWith
+// Close with why, subscribers get it in Disconnected.
+func (c *Client) closeWith(reason error) {
+	closed := false
+	var from ClientState
+	c.closeOnce.Do(func() {
+		closed = true
+		from = ClientState(c.state.Swap(int32(ClientClosed)))
 		close(c.Closing)
-		c.Closing = nil
+		c.writer.mu.Lock()
+		ses := c.Sess
+		c.writer.mu.Unlock()
+		if ses != nil {
+			_ = ses.CloseWithError(0, "")
+		}
+	})
+	if !closed {
+		return
 	}
+	// Outside Do, a subscriber calling Close from these would wait on it forever.
+	if from != ClientClosed {
+		c.emit(StateChanged{From: from, To: ClientClosed, Reason: reason})
+	}
+	c.emit(Disconnected{Reason: reason})
 }
 
 func (c *Client) runGarbage() {
@@ -201,13 +349,24 @@ func (c *Client) runGarbage() {
 		case <-c.Closing:
 			return
 		case <-c.garbageTicker.C:
-			// Bad way to do acks
-			// you miss a whole tick
-			if c.garbageWait.Load() {
+			ch := c.garbage.Load()
+			if ch == nil {
+				goto cRunGarbage
+			}
+			sent := ch.sent.Load()
+			if ch.batches != 0 && sent >= ch.batches {
+				// All sent, wait for the next challenge.
+				goto cRunGarbage
+			}
+			// Window full, this tick gets skipped.
+			if sent-ch.acked.Load() >= max(ch.window, 1) {
+				c.garbageStatMu.Lock()
+				c.garbageStats.Stalls++
+				c.garbageStatMu.Unlock()
 				goto cRunGarbage
 			}
 			// False is an error
-			if !c.sendGarbage() {
+			if !c.sendGarbage(ch, sent+1) {
 				c.garbageTicker.Stop()
 				goto cRunGarbage
 			}
@@ -216,7 +375,39 @@ func (c *Client) runGarbage() {
 	}
 }
 
-func (c *Client) sendGarbage() bool {
+// garbageData
+// What goes in the next garbage message, work mode does the work here.
+// Nil if the client closed while working.
+func (c *Client) garbageData(ch *clientChallenge) [][]byte {
+	if c.garbageNonceOf != ch {
+		c.garbageNonce = 0
+		c.garbageNonceOf = ch
+	}
+	data := make([][]byte, ch.amount)
+	for i := range data {
+		if ch.mode == cpnp.GarbageMode_work {
+			nonce, ok := SolveGarbageWork(ch.hash, ch.base, ch.diff, c.garbageNonce, c.Closing)
+			if !ok {
+				return nil
+			}
+			c.garbageNonce = nonce
+			data[i] = binary.BigEndian.AppendUint64(nil, nonce)
+			continue
+		}
+		data[i] = GarbageDigest(ch.hash, ch.encoding, ch.base, i)
+	}
+	return data
+}
+
+// sendGarbage
+// One batch of a challenge, everything comes from ch even if a new one arrives meanwhile.
+func (c *Client) sendGarbage(ch *clientChallenge, batch uint32) bool {
+	// Before the lock, work mode can take a while.
+	data := c.garbageData(ch)
+	if data == nil {
+		return false
+	}
+
 	c.writer.mu.Lock()
 	defer c.writer.mu.Unlock()
 
@@ -227,12 +418,12 @@ func (c *Client) sendGarbage() bool {
 	// 	return false
 	// }
 	// msg, err := cpnp.NewRootGameClientGarbage(seg)
-	if err != nil || !msg.IsValid() || c.garbageAmount == 0 {
+	if err != nil || !msg.IsValid() || ch.amount == 0 {
 		return false
 	}
 
 	// Create hashes
-	hashes, err := msg.NewHash(int32(c.garbageAmount))
+	hashes, err := msg.NewHash(int32(ch.amount))
 	if err != nil || !hashes.IsValid() {
 		return false
 	}
@@ -244,11 +435,8 @@ func (c *Client) sendGarbage() bool {
 			return false
 		}
 
-		// Probably wrong way to do hash
-		sh := sha1.Sum([]byte(fmt.Sprintf("%s%d", c.garbageBase, i)))
-
 		// Why does this fail?
-		err = garb.SetData(sh[:])
+		err = garb.SetData(data[i])
 		if err != nil {
 			return false
 		}
@@ -261,6 +449,8 @@ func (c *Client) sendGarbage() bool {
 	if err != nil {
 		return false
 	}
+	msg.SetBatch(batch)
+	msg.SetChallenge(ch.id)
 
 	// Write
 	_, err = SendStream(c.writer, c.Stream, msg.Message(), OpCodeCGarbage)
@@ -268,7 +458,95 @@ func (c *Client) sendGarbage() bool {
 		return false
 	}
 
-	// Wait for ack
-	c.garbageWait.Store(true)
+	if ch.sent.CompareAndSwap(batch-1, batch) {
+		c.garbageStatMu.Lock()
+		c.garbageStats.Sent++
+		c.garbageStatMu.Unlock()
+	}
 	return true
 }
+
+// ClientGarbageStats
+// Garbage batches since connecting.
+type ClientGarbageStats struct {
+	Sent  int
+	Acked int
+	// Stalls ticks skipped because the window was full.
+	Stalls int
+}
+
+// GarbageStats
+// Counts for measuring garbage throughput.
+func (c *Client) GarbageStats() ClientGarbageStats {
+	c.garbageStatMu.Lock()
+	defer c.garbageStatMu.Unlock()
+	return c.garbageStats
+}
+
+// MoveTo
+// Asks the server to walk us to a cell.
+func (c *Client) MoveTo(x, y int) error {
+	err := c.player()
+	if err != nil {
+		return err
+	}
+	err = clientSend(c, OpCodeCMoveTo, cpnp.NewRootGameClientMoveTo, func(msg cpnp.GameClientMoveTo) error {
+		msg.SetX(int32(x))
+		msg.SetY(int32(y))
+		return nil
+	})
+	if err == nil {
+		c.pathing.Store(true)
+	}
+	return err
+}
+
+// Pathing
+// If the server is still walking a MoveTo.
+func (c *Client) Pathing() bool {
+	return c.pathing.Load()
+}
+
+// ChatHistory
+// Asks for older chat, before is the oldest seq we have or 0 for the newest.
+func (c *Client) ChatHistory(kind cpnp.ChatKind, before uint64, count uint16) error {
+	return clientSend(c, OpCodeCChatHistory, cpnp.NewRootGameClientChatHistory, func(msg cpnp.GameClientChatHistory) error {
+		msg.SetKind(kind)
+		msg.SetBefore(before)
+		msg.SetCount(count)
+		return nil
+	})
+}
+
+// Spectate
+// Changes who a spectator follows, empty for the whole world.
+func (c *Client) Spectate(follow string) error {
+	if !c.spectator {
+		return ErrClientNotSpectator
+	}
+	return clientSend(c, OpCodeCSpectate, cpnp.NewRootGameClientSpectate, func(msg cpnp.GameClientSpectate) error {
+		return msg.SetFollow(follow)
+	})
+}
+
+// Rename
+// Asks the server to change our name, a notice comes back if it can't.
+func (c *Client) Rename(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrClientEmpty
+	}
+	return clientSend(c, OpCodeCRename, cpnp.NewRootGameClientRename, func(msg cpnp.GameClientRename) error {
+		return msg.SetName(name)
+	})
+}
+
+// ChangeWorld
+// Asks the server to move us to another world.
+func (c *Client) ChangeWorld(name string) error {
+	if name == "" {
+		return ErrClientEmpty
+	}
+	return clientSend(c, OpCodeCChangeWorld, cpnp.NewRootGameClientChangeWorld, func(msg cpnp.GameClientChangeWorld) error {
+		return msg.SetName(name)
+	})
+}
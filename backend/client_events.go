@@ -0,0 +1,176 @@
+package backend
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+// ClientEvent
+// Something that happened to a client, one of the types below.
+type ClientEvent interface {
+	clientEvent()
+}
+
+// PlayerJoined
+// Someone is in our world, also sent for everyone already there when we join one.
+type PlayerJoined struct {
+	Player WorldPlayer
+}
+
+// PlayerLeft
+// Someone left our world, or we did.
+type PlayerLeft struct {
+	ID   string
+	Name string
+}
+
+// PlayerMoved
+// Someone's position from the server, us included.
+type PlayerMoved struct {
+	Player WorldPlayer
+}
+
+// ChatReceived
+// A chat message, ID is empty for the server.
+type ChatReceived struct {
+	Kind   cpnp.ChatKind
+	ID     string
+	Name   string
+	Text   string
+	Target string
+}
+
+// Disconnected
+// The connection is gone, Reason is nil after Close.
+type Disconnected struct {
+	Reason error
+}
+
+// LatencyUpdated
+// Round trip of a move until the server acked it.
+// Average is smoothed over the last few.
+type LatencyUpdated struct {
+	RTT     time.Duration
+	Average time.Duration
+}
+
+func (PlayerJoined) clientEvent()   {}
+func (PlayerLeft) clientEvent()     {}
+func (PlayerMoved) clientEvent()    {}
+func (ChatReceived) clientEvent()   {}
+func (Disconnected) clientEvent()   {}
+func (LatencyUpdated) clientEvent() {}
+
+// clientEvents
+// Subscribers by id so they can be removed.
+type clientEvents struct {
+	mu   sync.RWMutex
+	next int
+	subs map[int]func(ClientEvent)
+
+	// rtt smoothed latency in nanoseconds.
+	rtt atomic.Int64
+}
+
+// Subscribe
+// Calls fn with every event until the returned func is called.
+// fn runs on the client's packet loop so it shouldn't block, see Events.
+func (c *Client) Subscribe(fn func(ClientEvent)) (unsubscribe func()) {
+	e := &c.events
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.subs == nil {
+		e.subs = make(map[int]func(ClientEvent))
+	}
+	id := e.next
+	e.next++
+	e.subs[id] = fn
+	return func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subs, id)
+	}
+}
+
+// OnEvent
+// Subscribe to one kind of event.
+//
+//	OnEvent(c, func(ev ChatReceived) { ... })
+func OnEvent[T ClientEvent](c *Client, fn func(T)) (unsubscribe func()) {
+	return c.Subscribe(func(ev ClientEvent) {
+		if t, ok := ev.(T); ok {
+			fn(t)
+		}
+	})
+}
+
+// Events
+// Every event on a channel holding size of them.
+// Events are dropped while it's full rather than holding up the client.
+// The channel is closed by the returned func.
+func (c *Client) Events(size int) (<-chan ClientEvent, func()) {
+	ch := make(chan ClientEvent, size)
+	var mu sync.Mutex
+	closed := false
+	unsubscribe := c.Subscribe(func(ev ClientEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- ev:
+		default:
+		}
+	})
+	return ch, func() {
+		unsubscribe()
+		mu.Lock()
+		defer mu.Unlock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+	}
+}
+
+// emit
+// Hands an event to every subscriber, outside the lock so they can unsubscribe.
+func (c *Client) emit(ev ClientEvent) {
+	e := &c.events
+	e.mu.RLock()
+	if len(e.subs) == 0 {
+		e.mu.RUnlock()
+		return
+	}
+	subs := make([]func(ClientEvent), 0, len(e.subs))
+	for _, fn := range e.subs {
+		subs = append(subs, fn)
+	}
+	e.mu.RUnlock()
+	for _, fn := range subs {
+		fn(ev)
+	}
+}
+
+// Latency
+// Smoothed move round trip, 0 before the first ack.
+func (c *Client) Latency() time.Duration {
+	return time.Duration(c.events.rtt.Load())
+}
+
+// latency
+// Another round trip, averaged like TCP does with an eighth of the new one.
+func (c *Client) latency(rtt time.Duration) {
+	avg := time.Duration(c.events.rtt.Load())
+	if avg == 0 {
+		avg = rtt
+	} else {
+		avg += (rtt - avg) / 8
+	}
+	c.events.rtt.Store(int64(avg))
+	c.emit(LatencyUpdated{RTT: rtt, Average: avg})
+}
@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClientEvents(t *testing.T) {
+	c := newClient(ClientConnection{Name: "bot"})
+	writer := NewPacketWriter()
+
+	var all []ClientEvent
+	stop := c.Subscribe(func(ev ClientEvent) { all = append(all, ev) })
+	var moves []PlayerMoved
+	OnEvent(c, func(ev PlayerMoved) { moves = append(moves, ev) })
+	ch, closeCh := c.Events(1)
+
+	c.mirror.reset("main")
+	c.HandlePlayers(testPlayers(t, writer, []WorldPlayer{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}))
+	c.HandleBPlayerMoved(testMoved(t, writer, WorldPlayer{ID: "a", Name: "A", X: 3, Y: 4}))
+	if len(all) != 3 {
+		t.Fatalf("expected two joins and a move, got %+v", all)
+	}
+	if _, ok := all[0].(PlayerJoined); !ok {
+		t.Errorf("first event %T", all[0])
+	}
+	if len(moves) != 1 || moves[0].Player.X != 3 || moves[0].Player.Y != 4 {
+		t.Errorf("typed moves %+v", moves)
+	}
+
+	// Channel only held the first, the rest were dropped.
+	if ev := <-ch; ev != all[0] {
+		t.Errorf("channel got %+v", ev)
+	}
+	closeCh()
+	if _, ok := <-ch; ok {
+		t.Error("channel still open")
+	}
+
+	// B missing from the next list is a leave.
+	c.HandlePlayers(testPlayers(t, writer, []WorldPlayer{{ID: "a", Name: "A", X: 3, Y: 4}}))
+	if left, ok := all[len(all)-1].(PlayerLeft); !ok || left.ID != "b" {
+		t.Errorf("expected b to leave, got %+v", all[len(all)-1])
+	}
+
+	stop()
+	n := len(all)
+	c.Close()
+	if len(all) != n {
+		t.Error("got events after unsubscribing")
+	}
+
+	var reason *Disconnected
+	d := newClient(ClientConnection{Name: "bot"})
+	OnEvent(d, func(ev Disconnected) { reason = &ev })
+	d.Close()
+	d.Close()
+	if reason == nil || reason.Reason != nil {
+		t.Errorf("close should be a disconnect without a reason, got %+v", reason)
+	}
+
+	// Closing again from the callback is normal cleanup.
+	e := newClient(ClientConnection{Name: "bot"})
+	OnEvent(e, func(Disconnected) { e.Close() })
+	done := make(chan struct{})
+	go func() {
+		e.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close from Disconnected deadlocked")
+	}
+}
+
+func TestClientLatency(t *testing.T) {
+	c := newClient(ClientConnection{Name: "bot"})
+	var got []LatencyUpdated
+	OnEvent(c, func(ev LatencyUpdated) { got = append(got, ev) })
+	c.latency(80 * time.Millisecond)
+	c.latency(160 * time.Millisecond)
+	if len(got) != 2 || got[0].Average != 80*time.Millisecond || got[1].Average != 90*time.Millisecond {
+		t.Errorf("latency %+v", got)
+	}
+	if c.Latency() != 90*time.Millisecond {
+		t.Errorf("Latency() %s", c.Latency())
+	}
+}
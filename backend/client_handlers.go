@@ -1,7 +1,7 @@
 package backend
 
 import (
-	"fmt"
+	"bytes"
 	"log"
 	"strings"
 	"time"
@@ -16,9 +16,20 @@ func (c *Client) setupHandlers() {
 	c.AddHandler(OpCodeBConnect, c.HandleBConnect)
 	c.AddHandler(OpCodeBPlayerMoved, c.HandleBPlayerMoved)
 	c.AddHandler(OpCodeBChat, c.HandleBChat)
+	c.AddHandler(OpCodeBPresence, c.HandleBPresence)
+	c.AddHandler(OpCodeBItem, c.HandleBItem)
+	c.AddHandler(OpCodeBRename, c.HandleBRename)
+	c.AddHandler(OpCodeSItems, c.HandleItems)
+	c.AddHandler(OpCodeSInventory, c.HandleInventory)
+	c.AddHandler(OpCodeSPathStep, c.HandlePathStep)
 	c.AddHandler(OpCodeSGarbage, c.HandleGarbageRequest)
 	c.AddHandler(OpCodeSPlayers, c.HandlePlayers)
 	c.AddHandler(OpCodeSGarbageAck, c.HandleGarbageAck)
+	c.AddHandler(OpCodeSMoveAck, c.HandleMoveAck)
+	c.AddHandler(OpCodeSMoveCorrection, c.HandleMoveCorrection)
+	c.AddHandler(OpCodeSNotice, c.HandleNotice)
+	c.AddHandler(OpCodeSWorld, c.HandleWorld)
+	c.AddHandler(OpCodeSChatHistory, c.HandleChatHistory)
 }
 
 // HandlePing
@@ -53,7 +64,6 @@ func (c *Client) HandlePing(payload []byte) {
 // HandleBConnect
 // Broadcast OpCodeBConnect
 func (c *Client) HandleBConnect(payload []byte) {
-	// log.Println("Client: Handling player connected")
 	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameBroadcastConnect)
 	if !valid {
 		log.Println("Client: Invalid connect message")
@@ -68,38 +78,27 @@ func (c *Client) HandleBConnect(payload []byte) {
 		log.Printf("Client: Error getting player: %v\n", err)
 		return
 	}
-	if !who.HasId() {
-		log.Println("Client: No ID in connect message")
+	p, err := worldPlayer(who)
+	if err != nil {
+		log.Printf("Client: Error getting player: %v\n", err)
 		return
 	}
-	if !who.HasName() {
-		log.Println("Client: No Name in connect message")
+	if !msg.Connected() {
+		c.mirror.disconnect(p.ID)
+		c.emit(PlayerLeft{ID: p.ID, Name: p.Name})
 		return
 	}
-
-	// Commented this out for testing lots of clients.
-
-	// name, err := who.Name()
-	// if err != nil {
-	// 	log.Printf("Client: Error getting name: %v\n", err)
-	// 	return
-	// }
-	// id, err := who.Id()
-	// if err != nil {
-	// 	log.Printf("Client: Error getting id: %v\n", err)
-	// 	return
-	// }
-	// conn := "connected"
-	// if !msg.Connected() {
-	// 	conn = "disconnected"
-	// }
-	// log.Printf("Client: Player: %s %s. (ID: %s)\n", name, conn, id)
+	me, err := CleanName(c.Name)
+	if err != nil {
+		me = c.Name
+	}
+	c.mirror.connect(p, me)
+	c.emit(PlayerJoined{Player: p})
 }
 
 // HandleBPlayerMoved
 // Broadcast OpCodeBPlayerMoved
 func (c *Client) HandleBPlayerMoved(payload []byte) {
-	// log.Println("Client: Handling player moved")
 	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameBroadcastPlayerMove)
 	if !valid {
 		log.Printf("Client: Invalid message. Len %d\n", len(payload))
@@ -113,32 +112,12 @@ func (c *Client) HandleBPlayerMoved(payload []byte) {
 		log.Printf("Client: Error getting who: %v\n", err)
 		return
 	}
-	if !who.HasId() {
-		return
-	}
-	// uid, err := who.Id()
-	_, err = who.Id()
+	p, err := worldPlayer(who)
 	if err != nil {
 		log.Printf("Client: Error getting id: %v\n", err)
 		return
 	}
-	x := who.X()
-	y := who.Y()
-	xdir := ""
-	ydir := ""
-	if x > 0 {
-		xdir = "East"
-	} else if x < 0 {
-		xdir = "West"
-	}
-	if y > 0 {
-		ydir = "North"
-	} else if y < 0 {
-		ydir = "South"
-	}
-	out := fmt.Sprintf("%s %s", ydir, xdir)
-	out = strings.TrimLeft(out, " ")
-	// log.Printf("Client: Player (%s): Moved %s", uid, out)
+	c.emit(PlayerMoved{Player: c.mirror.move(p.ID, p.Name, p.X, p.Y)})
 }
 
 // HandleBChat
@@ -154,19 +133,95 @@ func (c *Client) HandleBChat(payload []byte) {
 		return
 	}
 
-	// name, err := msg.Name()
-	// if err != nil {
-	// 	log.Printf("Client: Error getting name: %v\n", err)
-	// 	return
-	// }
-	// chat, err := msg.Text()
-	// if err != nil {
-	// 	log.Printf("Client: Error getting chat: %v\n", err)
-	// 	return
-	// }
+	name, err := msg.Name()
+	if err != nil {
+		log.Printf("Client: Error getting name: %v\n", err)
+		return
+	}
+	chat, err := msg.Text()
+	if err != nil {
+		log.Printf("Client: Error getting chat: %v\n", err)
+		return
+	}
+	id, _ := msg.Id()
+	target, _ := msg.Target()
+
+	// Not logged for go clients, subscribe to see it.
+	c.emit(ChatReceived{Kind: msg.Kind(), ID: id, Name: name, Text: chat, Target: target})
+}
+
+// HandleBPresence
+// Broadcast OpCodeBPresence
+func (c *Client) HandleBPresence(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameBroadcastPresence)
+	if !valid {
+		log.Printf("Client %s: Invalid presence. Len %d\n", c.Name, len(payload))
+		return
+	}
+	id, err := msg.Id()
+	if err != nil {
+		log.Printf("Client: Error getting presence id: %v\n", err)
+		return
+	}
+	c.mirror.presence(id, msg.Presence())
+}
+
+// HandleBItem
+// Broadcast OpCodeBItem
+func (c *Client) HandleBItem(payload []byte) {
+	_, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameBroadcastItem)
+	if !valid {
+		log.Printf("Client %s: Invalid item. Len %d\n", c.Name, len(payload))
+	}
+}
+
+// HandleBRename
+// Broadcast OpCodeBRename
+func (c *Client) HandleBRename(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameBroadcastRename)
+	if !valid {
+		log.Printf("Client %s: Invalid rename. Len %d\n", c.Name, len(payload))
+		return
+	}
+	id, err := msg.Id()
+	if err != nil {
+		log.Printf("Client: Error getting rename id: %v\n", err)
+		return
+	}
+	name, err := msg.Name()
+	if err != nil {
+		log.Printf("Client: Error getting new name: %v\n", err)
+		return
+	}
+	c.mirror.rename(id, name)
+}
+
+func (c *Client) HandleItems(payload []byte) {
+	_, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerItems)
+	if !valid {
+		log.Printf("Client %s: Invalid item list. Len %d\n", c.Name, len(payload))
+	}
+}
 
-	// Turned off for go clients
-	// log.Printf("Client: %s: %s\n", name, chat)
+func (c *Client) HandleInventory(payload []byte) {
+	_, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerInventory)
+	if !valid {
+		log.Printf("Client %s: Invalid inventory. Len %d\n", c.Name, len(payload))
+	}
+}
+
+// HandleChatHistory
+// Server OpCodeSChatHistory
+func (c *Client) HandleChatHistory(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerChatHistory)
+	if !valid {
+		log.Printf("Client: Invalid chat history. Len %d\n", len(payload))
+		return
+	}
+	if !msg.HasEntries() {
+		return
+	}
+	// Go clients don't show chat, same as HandleBChat.
 }
 
 func (c *Client) HandleGarbageRequest(payload []byte) {
@@ -201,27 +256,145 @@ func (c *Client) HandleGarbageRequest(payload []byte) {
 	} else {
 		c.garbageTicker.Reset(ntime)
 	}
-	c.garbageAmount = int(msg.Amount())
-	c.garbageBase = base
-	c.garbageWait.Store(false)
+	// base is in the packet buffer, which gets reused.
+	c.garbage.Store(&clientChallenge{
+		id:       msg.Challenge(),
+		batches:  msg.Batches(),
+		window:   uint32(msg.Window()),
+		amount:   int(msg.Amount()),
+		base:     bytes.Clone(base),
+		hash:     msg.Hash(),
+		encoding: msg.Encoding(),
+		mode:     msg.Mode(),
+		diff:     int(msg.Difficulty()),
+	})
 
 	// log.Printf("Client %s: Garbage needed %d/%ds", c.Name, c.garbageAmount, msg.Per())
 }
 
 func (c *Client) HandleGarbageAck(payload []byte) {
-	_, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerGarbageAck)
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerGarbageAck)
 	if !valid {
 		log.Printf("Client %s: Invalid garbage ack. Len %d\n", c.Name, len(payload))
 		return
 	}
-	c.garbageWait.Store(false)
+	ch := c.garbage.Load()
+	if ch == nil || msg.Challenge() != ch.id {
+		return
+	}
+	// Acks cover everything before them, one can stand in for a few.
+	for {
+		acked := ch.acked.Load()
+		if msg.Ack() <= acked {
+			return
+		}
+		if ch.acked.CompareAndSwap(acked, msg.Ack()) {
+			c.garbageStatMu.Lock()
+			c.garbageStats.Acked += int(msg.Ack() - acked)
+			c.garbageStatMu.Unlock()
+			return
+		}
+	}
 }
 
 func (c *Client) HandlePlayers(payload []byte) {
-	_, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerPlayers)
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerPlayers)
 	if !valid {
 		log.Printf("Client %s: Invalid player list. Len %d\n", c.Name, len(payload))
 		return
 	}
-	// Maybe print amount of players?
+	pList, err := msg.Players()
+	if err != nil {
+		log.Printf("Client: Error getting players: %v\n", err)
+		return
+	}
+	list := make([]WorldPlayer, 0, pList.Len())
+	for i := range pList.Len() {
+		p, err := worldPlayer(pList.At(i))
+		if err != nil {
+			log.Printf("Client: Error getting player: %v\n", err)
+			return
+		}
+		list = append(list, p)
+	}
+	before := c.mirror.Players()
+	diff := c.mirror.sync(list)
+	c.emitChanges(before, list)
+	if len(diff) > 0 {
+		log.Printf("Client %s: world drifted from the server: %s\n", c.Name, strings.Join(diff, "; "))
+	}
+}
+
+func (c *Client) HandleMoveAck(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerMoveAck)
+	if !valid {
+		log.Printf("Client %s: Invalid move ack. Len %d\n", c.Name, len(payload))
+		return
+	}
+	rtt := c.mover.reconcile(msg.Seq(), int(msg.X()), int(msg.Y()))
+	if rtt > 0 {
+		c.latency(rtt)
+	}
+}
+
+func (c *Client) HandleMoveCorrection(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerMoveCorrection)
+	if !valid {
+		log.Printf("Client %s: Invalid move correction. Len %d\n", c.Name, len(payload))
+		return
+	}
+	c.mover.reconcile(0, int(msg.X()), int(msg.Y()))
+}
+
+func (c *Client) HandlePathStep(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerPathStep)
+	if !valid {
+		log.Printf("Client %s: Invalid path step. Len %d\n", c.Name, len(payload))
+		return
+	}
+	c.pathing.Store(msg.Status() == cpnp.PathStatus_moving)
+	c.mover.reconcile(0, int(msg.X()), int(msg.Y()))
+}
+
+func (c *Client) HandleNotice(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerNotice)
+	if !valid {
+		log.Printf("Client %s: Invalid notice. Len %d\n", c.Name, len(payload))
+		return
+	}
+	txt, err := msg.Text()
+	if err != nil {
+		log.Printf("Client: Error getting notice text: %v\n", err)
+		return
+	}
+	log.Printf("Client %s: %s: %s\n", c.Name, msg.Kind(), txt)
+}
+
+func (c *Client) HandleWorld(payload []byte) {
+	msg, valid := DeserializeValid(c.reader, payload, cpnp.ReadRootGameServerWorld)
+	if !valid {
+		log.Printf("Client %s: Invalid world. Len %d\n", c.Name, len(payload))
+		return
+	}
+	name, err := msg.Name()
+	if err != nil {
+		log.Printf("Client: Error getting world name: %v\n", err)
+		return
+	}
+	world := NewGameMap(int(msg.Width()), int(msg.Height()), false)
+	if msg.HasWalls() {
+		walls, err := msg.Walls()
+		if err != nil {
+			log.Printf("Client: Error getting walls: %v\n", err)
+			return
+		}
+		for i := range walls.Len() {
+			_ = world.AddWall(int(walls.At(i).X()), int(walls.At(i).Y()))
+		}
+	}
+	c.World = name
+	c.mover.setWorld(world)
+	before := c.mirror.Players()
+	c.mirror.reset(name)
+	c.emitChanges(before, nil)
 }
@@ -0,0 +1,224 @@
+package backend
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+var ErrClientNoStream = errors.New("client has no stream")
+
+// PredictionStats
+// How far off the client was compared to the server.
+type PredictionStats struct {
+	// Acks received
+	Acks int
+	// Acks where the predicted position was wrong
+	Mispredicted int
+	// Sum of the distance between predicted and actual
+	TotalError int
+	MaxError   int
+}
+
+// pendingMove
+// A move that was sent but not acked yet.
+type pendingMove struct {
+	seq    uint32
+	dx, dy int
+	// Where the client thought it would end up.
+	x, y  int
+	known bool
+	// sent when Move was called, for the round trip.
+	sent time.Time
+}
+
+// clientMover
+// Client side prediction state.
+type clientMover struct {
+	mu      sync.Mutex
+	seq     uint32
+	pending []pendingMove
+	x, y    int
+	// Haven't heard from the server yet so no idea where we are.
+	known bool
+	stats PredictionStats
+
+	// The map of the world we are in, nil until the server sends it.
+	world *GameMap
+
+	// Simulated latency on outgoing moves
+	latency time.Duration
+	queue   chan queuedMove
+}
+
+type queuedMove struct {
+	at     time.Time
+	seq    uint32
+	dx, dy int8
+}
+
+// step
+// Where a move would end up, expects m.mu to be held.
+// Before the server sends the map it's assumed to be the default size,
+// the server corrects us if it's not.
+func (m *clientMover) step(x, y, dx, dy int) (int, int) {
+	if m.world == nil {
+		m.world = NewGameMap(DefaultMapSize, DefaultMapSize, false)
+	}
+	nx, ny := m.world.Wrap(x+dx, y+dy)
+	if m.world.Wall(nx, ny) {
+		return x, y
+	}
+	return nx, ny
+}
+
+// setWorld
+// Joined a world, forget where we were.
+func (m *clientMover) setWorld(world *GameMap) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.world = world
+	m.known = false
+	m.pending = m.pending[:0]
+}
+
+// MapSize
+// Size of the map the server sent, 0 before it did.
+func (c *Client) MapSize() (int, int) {
+	c.mover.mu.Lock()
+	defer c.mover.mu.Unlock()
+	if c.mover.world == nil {
+		return 0, 0
+	}
+	return c.mover.world.Width, c.mover.world.Height
+}
+
+// Move
+// Moves the player a step and predicts where it ends up.
+func (c *Client) Move(dx, dy int8) error {
+	err := c.player()
+	if err != nil {
+		return err
+	}
+	m := &c.mover
+	m.mu.Lock()
+	m.seq++
+	seq := m.seq
+	pm := pendingMove{seq: seq, dx: sign(int(dx)), dy: sign(int(dy)), known: m.known, sent: time.Now()}
+	if m.known {
+		m.x, m.y = m.step(m.x, m.y, pm.dx, pm.dy)
+		pm.x, pm.y = m.x, m.y
+	}
+	m.pending = append(m.pending, pm)
+	m.mu.Unlock()
+
+	if m.latency > 0 {
+		select {
+		case m.queue <- queuedMove{time.Now().Add(m.latency), seq, dx, dy}:
+			return nil
+		case <-c.Closing:
+			return ErrClientClosed
+		}
+	}
+	return c.sendMove(seq, dx, dy)
+}
+
+// Position
+// The predicted position, ok is false until the server has told us where we are.
+func (c *Client) Position() (x, y int, ok bool) {
+	c.mover.mu.Lock()
+	defer c.mover.mu.Unlock()
+	return c.mover.x, c.mover.y, c.mover.known
+}
+
+// PredictionStats
+// Copy of the prediction stats so far.
+func (c *Client) PredictionStats() PredictionStats {
+	c.mover.mu.Lock()
+	defer c.mover.mu.Unlock()
+	return c.mover.stats
+}
+
+func (c *Client) sendMove(seq uint32, dx, dy int8) error {
+	return clientSend(c, OpCodeCMoved, cpnp.NewRootGameClientMoved, func(msg cpnp.GameClientMoved) error {
+		msg.SetX(dx)
+		msg.SetY(dy)
+		msg.SetSeq(seq)
+		return nil
+	})
+}
+
+// runMoves
+// Sends the delayed moves when simulating latency.
+func (c *Client) runMoves() {
+	m := &c.mover
+	for {
+		select {
+		case <-c.Closing:
+			return
+		case mv := <-m.queue:
+			time.Sleep(time.Until(mv.at))
+			_ = c.sendMove(mv.seq, mv.dx, mv.dy)
+		}
+	}
+}
+
+// reconcile
+// Server says after input seq we are at x, y.
+// Replay what hasn't been acked on top of that.
+// Returns how long ago seq was sent, 0 if it wasn't pending.
+func (m *clientMover) reconcile(seq uint32, x, y int) (rtt time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if seq != 0 {
+		m.stats.Acks++
+	}
+	keep := m.pending[:0]
+	for _, pm := range m.pending {
+		if seq != 0 && pm.seq == seq && !pm.sent.IsZero() {
+			rtt = time.Since(pm.sent)
+		}
+		if pm.seq == seq && pm.known {
+			off := abs(pm.x-x) + abs(pm.y-y)
+			if off != 0 {
+				m.stats.Mispredicted++
+				m.stats.TotalError += off
+				m.stats.MaxError = max(m.stats.MaxError, off)
+			}
+		}
+		if seq != 0 && pm.seq <= seq {
+			continue
+		}
+		keep = append(keep, pm)
+	}
+	m.pending = keep
+
+	m.x, m.y = x, y
+	m.known = true
+	for i := range m.pending {
+		pm := &m.pending[i]
+		m.x, m.y = m.step(m.x, m.y, pm.dx, pm.dy)
+		pm.x, pm.y = m.x, m.y
+		pm.known = true
+	}
+	return rtt
+}
+
+func sign(v int) int {
+	if v > 0 {
+		return 1
+	} else if v < 0 {
+		return -1
+	}
+	return 0
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
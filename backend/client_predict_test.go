@@ -0,0 +1,30 @@
+package backend
+
+import "testing"
+
+func TestReconcile(t *testing.T) {
+	var m clientMover
+
+	// First ack tells us where we are
+	m.pending = []pendingMove{{seq: 1, dx: 1}}
+	m.reconcile(1, 5, 5)
+	if m.x != 5 || m.y != 5 || !m.known {
+		t.Fatalf("got %d,%d known %v", m.x, m.y, m.known)
+	}
+
+	// Two moves in flight, server only saw the first and blocked it.
+	m.pending = []pendingMove{
+		{seq: 2, dx: 1, x: 6, y: 5, known: true},
+		{seq: 3, dy: 1, x: 6, y: 6, known: true},
+	}
+	m.reconcile(2, 5, 5)
+	if m.x != 5 || m.y != 6 {
+		t.Errorf("replay got %d,%d", m.x, m.y)
+	}
+	if len(m.pending) != 1 || m.pending[0].x != 5 {
+		t.Errorf("pending not replayed %+v", m.pending)
+	}
+	if m.stats.Acks != 2 || m.stats.Mispredicted != 1 || m.stats.TotalError != 1 {
+		t.Errorf("stats %+v", m.stats)
+	}
+}
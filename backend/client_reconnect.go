@@ -0,0 +1,176 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"time"
+
+	"github.com/quic-go/webtransport-go"
+)
+
+var (
+	ErrClientLogin  = errors.New("client login failed")
+	ErrClientGaveUp = errors.New("client gave up reconnecting")
+	// ErrClientSecret the name needs the secret from its first login, retrying won't help.
+	ErrClientSecret = errors.New("wrong secret for that name")
+	// ErrClientConflict still online from another address, works once the server drops it.
+	ErrClientConflict = errors.New("already online from somewhere else")
+)
+
+// ReconnectPolicy
+// How a client retries after its connection drops.
+// Each attempt logs in again and the server resumes the old session.
+type ReconnectPolicy struct {
+	// Attempts before giving up, 0 never reconnects.
+	Attempts int
+	// Initial wait before the first attempt, doubled each one after up to Max.
+	Initial time.Duration
+	Max     time.Duration
+	// Jitter randomises each wait by this fraction either way, 0.5 is 50-150%.
+	// Stops a server full of clients all coming back at once.
+	Jitter float64
+}
+
+// DefaultReconnectPolicy
+// About a minute of trying.
+func DefaultReconnectPolicy() ReconnectPolicy {
+	return ReconnectPolicy{
+		Attempts: 8,
+		Initial:  500 * time.Millisecond,
+		Max:      15 * time.Second,
+		Jitter:   0.5,
+	}
+}
+
+// Delay
+// How long to wait before attempt, counting from 1.
+func (p ReconnectPolicy) Delay(attempt int) time.Duration {
+	d := p.Initial
+	if d <= 0 {
+		d = 500 * time.Millisecond
+	}
+	for range attempt - 1 {
+		d *= 2
+		if p.Max > 0 && d >= p.Max {
+			break
+		}
+	}
+	if p.Max > 0 {
+		d = min(d, p.Max)
+	}
+	if p.Jitter > 0 {
+		j := min(p.Jitter, 1)
+		d = time.Duration(float64(d) * (1 - j + 2*j*rand.Float64()))
+	}
+	return d
+}
+
+// StateChanged
+// The client went from one state to another, Reason is why it dropped or closed.
+type StateChanged struct {
+	From, To ClientState
+	Reason   error
+}
+
+// ReconnectFailed
+// An attempt didn't work, the next is in Next.
+type ReconnectFailed struct {
+	Attempt int
+	Err     error
+	Next    time.Duration
+}
+
+func (StateChanged) clientEvent()    {}
+func (ReconnectFailed) clientEvent() {}
+
+// setState
+// Moves to a state and tells subscribers, false if already closed.
+func (c *Client) setState(to ClientState, reason error) bool {
+	for {
+		from := ClientState(c.state.Load())
+		if from == ClientClosed {
+			return false
+		}
+		if from == to {
+			return true
+		}
+		if c.state.CompareAndSwap(int32(from), int32(to)) {
+			c.emit(StateChanged{From: from, To: to, Reason: reason})
+			return true
+		}
+	}
+}
+
+// dropped
+// The stream ended, reconnect if the policy says to.
+func (c *Client) dropped(reason error) {
+	if c.State() == ClientClosed {
+		return
+	}
+	if c.conn.Reconnect.Attempts <= 0 {
+		c.closeWith(reason)
+		return
+	}
+	// The server sends a new challenge once we're back.
+	c.garbageTicker.Stop()
+	c.emit(Disconnected{Reason: reason})
+	if !c.setState(ClientReconnecting, reason) {
+		return
+	}
+	c.reconnect(reason)
+}
+
+// reconnect
+// Tries the policy's attempts, closes the client if none work.
+func (c *Client) reconnect(reason error) {
+	p := c.conn.Reconnect
+	c.writer.mu.Lock()
+	old := c.Sess
+	c.writer.mu.Unlock()
+	if old != nil {
+		_ = old.CloseWithError(0, "reconnecting")
+	}
+
+	err := reason
+	delay := p.Delay(1)
+	for attempt := 1; attempt <= p.Attempts; attempt++ {
+		select {
+		case <-c.Closing:
+			return
+		case <-time.After(delay):
+		}
+
+		var ses *webtransport.Session
+		ses, err = dialClient(&c.conn)
+		if err != nil {
+			// Retrying can't fix the secret, a conflict or anything else might go away.
+			if errors.Is(err, ErrClientSecret) {
+				c.emit(ReconnectFailed{Attempt: attempt, Err: err})
+				c.closeWith(fmt.Errorf("%w: %w", ErrClientGaveUp, err))
+				return
+			}
+			delay = 0
+			if attempt < p.Attempts {
+				delay = p.Delay(attempt + 1)
+			}
+			log.Printf("Client %s: reconnect attempt %d/%d: %v\n", c.Name, attempt, p.Attempts, err)
+			c.emit(ReconnectFailed{Attempt: attempt, Err: err, Next: delay})
+			continue
+		}
+
+		c.writer.mu.Lock()
+		c.Sess = ses
+		c.writer.mu.Unlock()
+		// Closed while dialing.
+		if !c.setState(ClientConnecting, nil) {
+			_ = ses.CloseWithError(0, "")
+			return
+		}
+		log.Printf("Client %s: reconnected after %d attempts\n", c.Name, attempt)
+		go c.HandleStream()
+		return
+	}
+	c.closeWith(fmt.Errorf("%w after %d attempts: %w", ErrClientGaveUp, p.Attempts, err))
+}
@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReconnectDelay(t *testing.T) {
+	p := ReconnectPolicy{Initial: 100 * time.Millisecond, Max: 500 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		4:  500 * time.Millisecond,
+		40: 500 * time.Millisecond,
+	} {
+		if got := p.Delay(attempt); got != want {
+			t.Errorf("attempt %d: %s, want %s", attempt, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		d := p.Delay(2)
+		if d < 100*time.Millisecond || d > 300*time.Millisecond {
+			t.Fatalf("jittered delay %s out of range", d)
+		}
+	}
+}
+
+func TestReconnectGivesUp(t *testing.T) {
+	c := newClient(ClientConnection{
+		Name: "bot",
+		IP:   "127.0.0.1",
+		// Nothing listens here so every attempt fails.
+		HTTPPort:  "1",
+		WTPort:    "1",
+		Reconnect: ReconnectPolicy{Attempts: 2, Initial: time.Millisecond},
+	})
+	events, stop := c.Events(16)
+	defer stop()
+
+	c.setState(ClientConnected, nil)
+	drop := errors.New("stream reset")
+	c.dropped(drop)
+
+	if c.State() != ClientClosed {
+		t.Fatalf("state %s, want closed", c.State())
+	}
+	var states []ClientState
+	failed := 0
+	var last Disconnected
+	for len(events) > 0 {
+		switch ev := (<-events).(type) {
+		case StateChanged:
+			states = append(states, ev.To)
+		case ReconnectFailed:
+			failed++
+		case Disconnected:
+			last = ev
+		}
+	}
+	if len(states) != 3 || states[0] != ClientConnected || states[1] != ClientReconnecting || states[2] != ClientClosed {
+		t.Errorf("states %v", states)
+	}
+	if failed != 2 {
+		t.Errorf("%d failed attempts, want 2", failed)
+	}
+	if !errors.Is(last.Reason, ErrClientGaveUp) {
+		t.Errorf("final disconnect %v", last.Reason)
+	}
+	if err := c.Chat("hi"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("chat after giving up: %v", err)
+	}
+}
+
+func TestDroppedWithoutPolicy(t *testing.T) {
+	c := newClient(ClientConnection{Name: "bot"})
+	var got *Disconnected
+	OnEvent(c, func(ev Disconnected) { got = &ev })
+	drop := errors.New("stream reset")
+	c.dropped(drop)
+	if c.State() != ClientClosed || got == nil || !errors.Is(got.Reason, drop) {
+		t.Errorf("state %s, disconnect %+v", c.State(), got)
+	}
+	if c.setState(ClientConnected, nil) {
+		t.Error("closed client changed state")
+	}
+}
+
+// waitState
+// Polls until c gets to state or a few seconds pass.
+func waitState(tb testing.TB, c *Client, state ClientState) {
+	deadline := time.Now().Add(5 * time.Second)
+	for c.State() != state {
+		if time.Now().After(deadline) {
+			tb.Fatalf("client %s: state %s, want %s", c.Name, c.State(), state)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestReconnectServer(t *testing.T) {
+	// Start uses fixed ports, the hash server exits if its port is taken.
+	hash, err := net.Listen("tcp", ":8775")
+	if err != nil {
+		t.Skipf("hash server port: %v", err)
+	}
+	_ = hash.Close()
+	srv := NewWebTransportServer(DefaultGameConfig())
+	if !srv.Start() {
+		t.Skip("can't start the WebTransport server")
+	}
+	defer srv.Stop()
+	login := httptest.NewServer(http.HandlerFunc(srv.HandleLogin))
+	defer login.Close()
+	_, port, _ := net.SplitHostPort(login.Listener.Addr().String())
+
+	cc := ClientConnection{Name: "Dropped Client", HTTPPort: port}
+	first, err := ClientConnect(cc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	waitState(t, first, ClientConnected)
+
+	uid, err := srv.db.GetUser(cc.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.sessions.mu.RLock()
+	session := srv.sessions.sessions[uid]
+	srv.sessions.mu.RUnlock()
+	w := srv.worlds.WorldOf(session)
+	w.pmu.RLock()
+	player := w.Players[session]
+	w.pmu.RUnlock()
+	if player == nil {
+		t.Fatal("no player for the first connection")
+	}
+
+	// The server still has the first one active, like a drop it hasn't noticed.
+	cc.Secret = first.Secret()
+	second, err := ClientConnect(cc)
+	if err != nil {
+		t.Fatalf("logging back in: %v", err)
+	}
+	defer second.Close()
+	waitState(t, second, ClientConnected)
+	// Replaced, and without a policy the first one closes.
+	waitState(t, first, ClientClosed)
+
+	srv.sessions.mu.RLock()
+	again := srv.sessions.sessions[uid]
+	srv.sessions.mu.RUnlock()
+	if again != session || !session.Active.Load() {
+		t.Fatal("didn't resume the same session")
+	}
+	if srv.worlds.WorldOf(session) != w {
+		t.Fatal("moved to another world")
+	}
+	w.pmu.RLock()
+	same := w.Players[session]
+	w.pmu.RUnlock()
+	if same != player {
+		t.Error("didn't keep the same player")
+	}
+
+	cc.Secret = ""
+	if _, err := ClientConnect(cc); !errors.Is(err, ErrClientSecret) {
+		t.Errorf("without the secret: %v", err)
+	}
+}
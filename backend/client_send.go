@@ -0,0 +1,152 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"capnproto.org/go/capnp/v3"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrClientClosed       = errors.New("client is closed")
+	ErrClientReconnecting = errors.New("client is reconnecting")
+	ErrClientSpectator    = errors.New("spectators can't do that")
+	ErrClientNotSpectator = errors.New("only spectators can do that")
+	ErrClientEmpty        = errors.New("nothing to send")
+	ErrClientChatKind     = errors.New("can't send that chat kind")
+)
+
+// ClientState
+// Where a client is in its connection.
+type ClientState int32
+
+const (
+	// ClientConnecting logged in, waiting for the server's stream.
+	ClientConnecting ClientState = iota
+	ClientConnected
+	// ClientReconnecting lost the connection, trying again, see ReconnectPolicy.
+	ClientReconnecting
+	ClientClosed
+)
+
+func (s ClientState) String() string {
+	switch s {
+	case ClientConnecting:
+		return "connecting"
+	case ClientConnected:
+		return "connected"
+	case ClientReconnecting:
+		return "reconnecting"
+	case ClientClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("ClientState(%d)", int32(s))
+}
+
+// State
+// Current connection state, safe to call from anywhere.
+func (c *Client) State() ClientState {
+	return ClientState(c.state.Load())
+}
+
+// ready
+// Error for sending in the current state, nil when connected.
+func (c *Client) ready() error {
+	switch c.State() {
+	case ClientConnecting:
+		return ErrClientNoStream
+	case ClientReconnecting:
+		return ErrClientReconnecting
+	case ClientClosed:
+		return ErrClientClosed
+	}
+	return nil
+}
+
+// player
+// Like ready, also refuses spectators.
+func (c *Client) player() error {
+	if c.spectator {
+		return ErrClientSpectator
+	}
+	return c.ready()
+}
+
+// clientSend
+// Builds and sends a message, QueueMessage for the client.
+func clientSend[T CapnpMessage](c *Client, opcode uint16, ctor func(*capnp.Segment) (T, error), build func(T) error) error {
+	err := c.ready()
+	if err != nil {
+		return err
+	}
+	c.writer.mu.Lock()
+	defer c.writer.mu.Unlock()
+	// Could have dropped while waiting on the lock.
+	if c.Stream == nil {
+		return c.ready()
+	}
+
+	msg, err := NewMessage(c.writer, ctor)
+	if err != nil {
+		return fmt.Errorf("new message: %w", err)
+	}
+	if build != nil {
+		err = build(msg)
+		if err != nil {
+			return fmt.Errorf("build message: %w", err)
+		}
+	}
+	_, err = SendStream(c.writer, c.Stream, msg.Message(), opcode)
+	if err == nil {
+		c.lastSent.Store(time.Now().UnixNano())
+	}
+	return err
+}
+
+// Chat
+// Says something to the world we are in.
+func (c *Client) Chat(text string) error {
+	return c.ChatIn(cpnp.ChatKind_world, text)
+}
+
+// ChatIn
+// Chat to world, global, proximity, party or as an emote.
+// Slash commands work the same as in the browser.
+func (c *Client) ChatIn(kind cpnp.ChatKind, text string) error {
+	switch kind {
+	case cpnp.ChatKind_whisper, cpnp.ChatKind_system:
+		return fmt.Errorf("%w: %s", ErrClientChatKind, kind)
+	}
+	return c.sendChat(kind, text, "")
+}
+
+// Whisper
+// Private message to a player by name or ID.
+func (c *Client) Whisper(to, text string) error {
+	if strings.TrimSpace(to) == "" {
+		return fmt.Errorf("%w: no one to whisper to", ErrClientEmpty)
+	}
+	return c.sendChat(cpnp.ChatKind_whisper, text, to)
+}
+
+func (c *Client) sendChat(kind cpnp.ChatKind, text, target string) error {
+	err := c.player()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(text) == "" {
+		return ErrClientEmpty
+	}
+	return clientSend(c, OpCodeCChat, cpnp.NewRootGameClientChat, func(msg cpnp.GameClientChat) error {
+		err := msg.SetText(text)
+		if err != nil {
+			return err
+		}
+		msg.SetKind(kind)
+		return msg.SetTarget(target)
+	})
+}
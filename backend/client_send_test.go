@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+
+	"simpleWT/backend/cpnp"
+)
+
+func TestClientSendState(t *testing.T) {
+	c := newClient(ClientConnection{Name: "bot"})
+	if c.State() != ClientConnecting {
+		t.Fatalf("new client %s", c.State())
+	}
+	if err := c.Chat("hi"); !errors.Is(err, ErrClientNoStream) {
+		t.Errorf("chat before stream: %v", err)
+	}
+	if err := c.ChatIn(cpnp.ChatKind_system, "hi"); !errors.Is(err, ErrClientChatKind) {
+		t.Errorf("system chat: %v", err)
+	}
+	if err := c.Whisper(" ", "hi"); !errors.Is(err, ErrClientEmpty) {
+		t.Errorf("whisper no one: %v", err)
+	}
+	if err := c.Spectate(""); !errors.Is(err, ErrClientNotSpectator) {
+		t.Errorf("player spectate: %v", err)
+	}
+
+	c.Close()
+	c.Close()
+	if c.State() != ClientClosed {
+		t.Fatalf("closed client %s", c.State())
+	}
+	if err := c.Move(1, 0); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("move after close: %v", err)
+	}
+	if err := c.ChangeWorld("other"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("change world after close: %v", err)
+	}
+
+	spec := newClient(ClientConnection{Name: "watcher", Spectate: true})
+	if err := spec.Chat("hi"); !errors.Is(err, ErrClientSpectator) {
+		t.Errorf("spectator chat: %v", err)
+	}
+	if err := spec.MoveTo(1, 1); !errors.Is(err, ErrClientSpectator) {
+		t.Errorf("spectator move to: %v", err)
+	}
+}
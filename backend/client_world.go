@@ -0,0 +1,301 @@
+package backend
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"simpleWT/backend/cpnp"
+)
+
+// WorldPlayer
+// Someone in a world, as the player list sends them.
+type WorldPlayer struct {
+	ID       string
+	Name     string
+	X, Y     int
+	Presence cpnp.Presence
+}
+
+// MirrorStats
+// How the mirror compared to the player lists the server sent.
+type MirrorStats struct {
+	// Checks player lists compared against the mirror.
+	Checks int
+	// Drifted checks that didn't match.
+	Drifted int
+	// LastDiff differences from the latest check, empty if it matched.
+	LastDiff []string
+}
+
+// WorldMirror
+// The client's copy of the players in its world.
+// Filled by the player list and kept up with broadcasts.
+// Every player list after the first is checked against it first.
+type WorldMirror struct {
+	mu      sync.RWMutex
+	world   string
+	self    string
+	players map[string]WorldPlayer
+	// synced has had a player list since joining the world.
+	synced bool
+	stats  MirrorStats
+}
+
+// reset
+// Joined a world, nothing is known about it yet.
+func (m *WorldMirror) reset(world string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.world = world
+	m.self = ""
+	m.players = make(map[string]WorldPlayer)
+	m.synced = false
+}
+
+func (m *WorldMirror) connect(p WorldPlayer, me string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.players == nil {
+		m.players = make(map[string]WorldPlayer)
+	}
+	// Our own connect is the first one after the world.
+	if m.self == "" && strings.EqualFold(p.Name, me) {
+		m.self = p.ID
+	}
+	m.players[p.ID] = p
+}
+
+func (m *WorldMirror) disconnect(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.players, id)
+}
+
+// move
+// Moves only carry the name and position, presence stays.
+func (m *WorldMirror) move(id, name string, x, y int) WorldPlayer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.players == nil {
+		m.players = make(map[string]WorldPlayer)
+	}
+	p, ok := m.players[id]
+	if !ok {
+		p.ID = id
+	}
+	p.Name, p.X, p.Y = name, x, y
+	m.players[id] = p
+	return p
+}
+
+func (m *WorldMirror) presence(id string, presence cpnp.Presence) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.players[id]
+	if !ok {
+		return
+	}
+	p.Presence = presence
+	m.players[id] = p
+}
+
+func (m *WorldMirror) rename(id, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.players[id]
+	if !ok {
+		return
+	}
+	p.Name = name
+	m.players[id] = p
+}
+
+// sync
+// Checks a player list against the mirror then takes it as the truth.
+// The first one after joining is only taken, there's nothing to check.
+func (m *WorldMirror) sync(list []WorldPlayer) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var diff []string
+	if m.synced {
+		diff = DiffPlayers(list, m.list())
+		m.stats.Checks++
+		if len(diff) > 0 {
+			m.stats.Drifted++
+		}
+		m.stats.LastDiff = diff
+	}
+	m.players = make(map[string]WorldPlayer, len(list))
+	for _, p := range list {
+		m.players[p.ID] = p
+	}
+	m.synced = true
+	return diff
+}
+
+// list
+// Players sorted by ID, expects m.mu to be held.
+func (m *WorldMirror) list() []WorldPlayer {
+	out := make([]WorldPlayer, 0, len(m.players))
+	for _, p := range m.players {
+		out = append(out, p)
+	}
+	slices.SortFunc(out, func(a, b WorldPlayer) int { return cmp.Compare(a.ID, b.ID) })
+	return out
+}
+
+// World
+// Name of the world the mirror is of.
+func (m *WorldMirror) World() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.world
+}
+
+// Self
+// Our own ID, empty until our connect came in.
+func (m *WorldMirror) Self() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.self
+}
+
+// Players
+// Everyone in the world sorted by ID, NPCs included.
+func (m *WorldMirror) Players() []WorldPlayer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.list()
+}
+
+// Player
+// One player by ID.
+func (m *WorldMirror) Player(id string) (WorldPlayer, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.players[id]
+	return p, ok
+}
+
+// Find
+// One player by name, ignoring case like the server does.
+func (m *WorldMirror) Find(name string) (WorldPlayer, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, p := range m.players {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return WorldPlayer{}, false
+}
+
+// Len
+// Players in the world.
+func (m *WorldMirror) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.players)
+}
+
+// Synced
+// If a player list came in since joining the world.
+func (m *WorldMirror) Synced() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.synced
+}
+
+// Stats
+// Copy of the check results so far.
+func (m *WorldMirror) Stats() MirrorStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	st := m.stats
+	st.LastDiff = slices.Clone(st.LastDiff)
+	return st
+}
+
+// DiffPlayers
+// What's different between two player lists, empty if they match.
+func DiffPlayers(want, got []WorldPlayer) []string {
+	index := make(map[string]WorldPlayer, len(got))
+	for _, p := range got {
+		index[p.ID] = p
+	}
+	var diff []string
+	for _, w := range want {
+		g, ok := index[w.ID]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("%s (%s): missing", w.ID, w.Name))
+			continue
+		}
+		delete(index, w.ID)
+		if g != w {
+			diff = append(diff, fmt.Sprintf("%s: want %s at %d,%d %s, got %s at %d,%d %s",
+				w.ID, w.Name, w.X, w.Y, w.Presence, g.Name, g.X, g.Y, g.Presence))
+		}
+	}
+	for _, g := range got {
+		if _, ok := index[g.ID]; ok {
+			diff = append(diff, fmt.Sprintf("%s (%s): extra", g.ID, g.Name))
+		}
+	}
+	return diff
+}
+
+// Mirror
+// The client's copy of its world.
+func (c *Client) Mirror() *WorldMirror {
+	return &c.mirror
+}
+
+// Resync
+// Asks the server for the player list again, the mirror gets checked against it.
+func (c *Client) Resync() error {
+	err := c.player()
+	if err != nil {
+		return err
+	}
+	return clientSend(c, OpCodeCPlayers, cpnp.NewRootGameClientPlayers, nil)
+}
+
+// emitChanges
+// Events for the difference between two player lists, joins, moves then leaves.
+func (c *Client) emitChanges(before, after []WorldPlayer) {
+	old := make(map[string]WorldPlayer, len(before))
+	for _, p := range before {
+		old[p.ID] = p
+	}
+	for _, p := range after {
+		was, ok := old[p.ID]
+		delete(old, p.ID)
+		if !ok {
+			c.emit(PlayerJoined{Player: p})
+		} else if was.X != p.X || was.Y != p.Y {
+			c.emit(PlayerMoved{Player: p})
+		}
+	}
+	for _, p := range before {
+		if _, ok := old[p.ID]; ok {
+			c.emit(PlayerLeft{ID: p.ID, Name: p.Name})
+		}
+	}
+}
+
+// worldPlayer
+// From the capnp player the server sends.
+func worldPlayer(p cpnp.Player) (WorldPlayer, error) {
+	id, err := p.Id()
+	if err != nil {
+		return WorldPlayer{}, err
+	}
+	name, err := p.Name()
+	if err != nil {
+		return WorldPlayer{}, err
+	}
+	return WorldPlayer{ID: id, Name: name, X: int(p.X()), Y: int(p.Y()), Presence: p.Presence()}, nil
+}
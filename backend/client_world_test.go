@@ -0,0 +1,122 @@
+package backend
+
+import (
+	"bytes"
+	"testing"
+
+	"capnproto.org/go/capnp/v3"
+
+	"simpleWT/backend/cpnp"
+)
+
+// testPayload
+// What a handler gets for msg, header stripped.
+func testPayload(tb testing.TB, writer *PacketWriter, msg *capnp.Message, opcode uint16) []byte {
+	buf := new(bytes.Buffer)
+	_, err := SendStream(writer, buf, msg, opcode)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return buf.Bytes()[PacketHeaderLength:]
+}
+
+func testPlayers(tb testing.TB, writer *PacketWriter, players []WorldPlayer) []byte {
+	msg, err := NewMessage(writer, cpnp.NewRootGameServerPlayers)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	pList, err := msg.NewPlayers(int32(len(players)))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for i, p := range players {
+		at := pList.At(i)
+		_ = at.SetId(p.ID)
+		_ = at.SetName(p.Name)
+		at.SetX(int32(p.X))
+		at.SetY(int32(p.Y))
+		at.SetPresence(p.Presence)
+	}
+	return testPayload(tb, writer, msg.Message(), OpCodeSPlayers)
+}
+
+func testMoved(tb testing.TB, writer *PacketWriter, p WorldPlayer) []byte {
+	msg, err := NewMessage(writer, cpnp.NewRootGameBroadcastPlayerMove)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	who, err := msg.NewWho()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	_ = who.SetId(p.ID)
+	_ = who.SetName(p.Name)
+	who.SetX(int32(p.X))
+	who.SetY(int32(p.Y))
+	return testPayload(tb, writer, msg.Message(), OpCodeBPlayerMoved)
+}
+
+func TestWorldMirror(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.MapWidth, c.MapHeight = 50, 50
+		c.Walls = 0
+	})
+	w := g.w
+
+	a := g.join(t, "")
+	g.join(t, "")
+
+	writer := NewPacketWriter()
+	c := newClient(ClientConnection{Name: "watcher"})
+	c.mirror.reset(w.Name)
+	c.HandlePlayers(testPlayers(t, writer, w.PlayerList()))
+	if c.Mirror().Len() != 2 || !c.Mirror().Synced() {
+		t.Fatalf("first list gave %d players", c.Mirror().Len())
+	}
+	if st := c.Mirror().Stats(); st.Checks != 0 {
+		t.Errorf("first list shouldn't be checked: %+v", st)
+	}
+
+	// Told about the move, the next list matches.
+	pa := w.Players[a]
+	if err := w.Map.Place(pa, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	moved, ok := c.Mirror().Player(a.ID.String())
+	if !ok {
+		t.Fatal("player missing from mirror")
+	}
+	moved.X, moved.Y = 10, 10
+	c.HandleBPlayerMoved(testMoved(t, writer, moved))
+	if got, _ := c.Mirror().Find(pa.Name); got.X != 10 || got.Y != 10 {
+		t.Errorf("move not mirrored: %+v", got)
+	}
+	c.HandlePlayers(testPlayers(t, writer, w.PlayerList()))
+	if st := c.Mirror().Stats(); st.Checks != 1 || st.Drifted != 0 {
+		t.Errorf("matching list: %+v", st)
+	}
+
+	// Missed move shows up as drift, then the list is taken.
+	if err := w.Map.Place(pa, 20, 20); err != nil {
+		t.Fatal(err)
+	}
+	c.HandlePlayers(testPlayers(t, writer, w.PlayerList()))
+	st := c.Mirror().Stats()
+	if st.Checks != 2 || st.Drifted != 1 || len(st.LastDiff) != 1 {
+		t.Errorf("drifted list: %+v", st)
+	}
+	if diff := DiffPlayers(w.PlayerList(), c.Mirror().Players()); len(diff) != 0 {
+		t.Errorf("mirror didn't converge: %v", diff)
+	}
+}
+
+func TestDiffPlayers(t *testing.T) {
+	want := []WorldPlayer{{ID: "a", Name: "A"}, {ID: "b", Name: "B", X: 1}}
+	got := []WorldPlayer{{ID: "b", Name: "B", X: 2}, {ID: "c", Name: "C"}}
+	if diff := DiffPlayers(want, got); len(diff) != 3 {
+		t.Errorf("expected missing, moved and extra, got %v", diff)
+	}
+	if diff := DiffPlayers(want, want); len(diff) != 0 {
+		t.Errorf("same lists differ: %v", diff)
+	}
+}
@@ -0,0 +1,251 @@
+package backend
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// GameConfig
+// Knobs for the game world.
+// Zero value is a usable config, see DefaultGameConfig for the normal one.
+type GameConfig struct {
+	// MapWidth, MapHeight size of the grid.
+	MapWidth  int
+	MapHeight int
+
+	// Collision only one player per cell.
+	Collision bool
+
+	// Walls random walls put on each map.
+	Walls int
+
+	// DefaultWorld the world players join if they don't pick one.
+	DefaultWorld string
+	// Worlds extra worlds to host, each has its own map and tick.
+	Worlds []WorldConfig
+
+	// TickRate how often the world updates.
+	TickRate time.Duration
+
+	// Movement limits per player.
+	Movement MovementConfig
+
+	// SpectatorView how many cells around a followed player spectators see moves for.
+	SpectatorView int
+
+	// ChatProximity how many cells away proximity chat reaches.
+	ChatProximity int
+
+	// Chat moderation.
+	Chat ChatConfig
+
+	// Rename how often a player can change their name.
+	Rename RateLimit
+	// Resync how often a player can ask for the player list again.
+	Resync RateLimit
+
+	// Presence when players count as idle or away.
+	Presence PresenceConfig
+
+	// NPC server controlled entities in each world.
+	NPC NPCConfig
+
+	// Items spawned on each map.
+	Items []ItemSpawn
+
+	// Seed for the maps, 0 is random. Recorded in the event log either way.
+	// Each world mixes its name in, see World.
+	Seed uint64
+	// EventLog file every world appends its events to, empty for none.
+	EventLog string
+	// ReservedSecrets file the login secrets of reserved names are appended to, owner only.
+	// Empty keeps them nowhere, a reserved name can't be logged in to without its secret.
+	ReservedSecrets string
+
+	// Snapshot saving and loading world state.
+	Snapshot SnapshotConfig
+
+	// Garbage challenges everyone gets.
+	Garbage GarbagePolicy
+	// GarbageUsers overrides by player name, reserved at startup and matched by user ID.
+	GarbageUsers map[string]GarbagePolicy
+	// AdaptiveGarbage asks for less garbage when the server is busy.
+	AdaptiveGarbage AdaptiveGarbageConfig
+}
+
+// WorldConfig
+// Overrides for a single world, zero values use the GameConfig ones.
+type WorldConfig struct {
+	Name      string
+	MapWidth  int
+	MapHeight int
+	Walls     int
+	NPCs      int
+	// Seed used as is, for bringing a world back from a snapshot.
+	Seed uint64
+}
+
+// MovementConfig
+// How fast players are allowed to move.
+type MovementConfig struct {
+	// Limit steps per second and burst.
+	Limit RateLimit
+	// Throttled limit used once a player gets throttled.
+	Throttled RateLimit
+	// Queue how many moves over the limit to hold onto for later ticks.
+	// Anything past this gets dropped and counts as a violation.
+	Queue int
+	// Escalation for dropped moves.
+	Escalation Escalation
+
+	// PathAvoidPlayers plan MoveTo paths around other players, only with Collision.
+	// Otherwise paths go through them and get cancelled if someone is in the way.
+	PathAvoidPlayers bool
+	// PathSearch most cells A* looks at, 0 is the whole map.
+	PathSearch int
+}
+
+// ChatConfig
+// What chat lets through.
+type ChatConfig struct {
+	// MaxLength characters per message, 0 is no limit.
+	MaxLength int
+	// Words masked out of messages.
+	Words []string
+	// Flood messages per second per player.
+	Flood RateLimit
+	// Moderators names allowed to /mute and /unmute.
+	// Reserved at startup, the log has the login secret of any that are new.
+	Moderators []string
+
+	// History messages kept per channel.
+	History int
+	// HistoryJoin messages per channel sent on join.
+	HistoryJoin int
+	// HistoryPage most messages a client can ask for at once.
+	HistoryPage int
+}
+
+// PresenceConfig
+// How long without input before a player shows as idle or away.
+// Zero turns that state off.
+type PresenceConfig struct {
+	Idle time.Duration
+	Away time.Duration
+}
+
+// NPCConfig
+// NPCs spawned with every world.
+type NPCConfig struct {
+	// Count NPCs per world, each gets a random behaviour.
+	Count int
+	// Speed steps per second.
+	Speed RateLimit
+}
+
+// SnapshotConfig
+// Where and how often world state gets saved.
+type SnapshotConfig struct {
+	// Path file to save to and load from at startup, empty for none.
+	Path string
+	// Every how often to save while running, always saves on shutdown.
+	Every time.Duration
+}
+
+// DefaultWorldName is the world everyone used to share.
+const DefaultWorldName = "main"
+
+// DefaultGameConfig
+// The settings the server has always run with.
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		MapWidth:     DefaultMapSize,
+		MapHeight:    DefaultMapSize,
+		DefaultWorld: DefaultWorldName,
+		TickRate:     50 * time.Millisecond,
+		Movement: MovementConfig{
+			Limit:     RateLimit{Rate: 10, Burst: 20},
+			Throttled: RateLimit{Rate: 2, Burst: 2},
+			Queue:     5,
+			Escalation: Escalation{
+				Warn:     10,
+				Throttle: 30,
+				Kick:     100,
+				Window:   10 * time.Second,
+			},
+		},
+		ChatProximity: 10,
+		SpectatorView: 15,
+		Chat: ChatConfig{
+			MaxLength: 256,
+			Flood:     RateLimit{Rate: 1, Burst: 5},
+
+			History:     200,
+			HistoryJoin: 20,
+			HistoryPage: 50,
+		},
+		// Two tries then one a minute.
+		Rename: RateLimit{Rate: 1.0 / 60, Burst: 2},
+		Resync: RateLimit{Rate: 1, Burst: 3},
+		Presence: PresenceConfig{
+			Idle: time.Minute,
+			Away: 5 * time.Minute,
+		},
+		NPC: NPCConfig{
+			Speed: RateLimit{Rate: 2, Burst: 1},
+		},
+		Items: []ItemSpawn{
+			{Kind: "coin", Count: 20, Respawn: 30 * time.Second},
+		},
+		Snapshot: SnapshotConfig{
+			Every: time.Minute,
+		},
+		Garbage: DefaultGarbagePolicy(),
+		AdaptiveGarbage: AdaptiveGarbageConfig{
+			Every:      5 * time.Second,
+			MinScale:   0.1,
+			MaxScale:   1,
+			Step:       0.25,
+			Queue:      1024,
+			Latency:    5 * time.Millisecond,
+			Goroutines: 20000,
+			// Half the tick rate.
+			TickTime: 25 * time.Millisecond,
+		},
+	}
+}
+
+// World
+// The config for a single world with the overrides applied.
+func (c GameConfig) World(wc WorldConfig) GameConfig {
+	if wc.MapWidth > 0 {
+		c.MapWidth = wc.MapWidth
+	}
+	if wc.MapHeight > 0 {
+		c.MapHeight = wc.MapHeight
+	}
+	if wc.Walls > 0 {
+		c.Walls = wc.Walls
+	}
+	if wc.NPCs > 0 {
+		c.NPC.Count = wc.NPCs
+	}
+	c.Seed = worldSeed(c.Seed, wc.Name)
+	if wc.Seed != 0 {
+		c.Seed = wc.Seed
+	}
+	c.Worlds = nil
+	return c
+}
+
+// worldSeed
+// Seed for a world, each world gets its own from the config one.
+// Zero config seed is random.
+func worldSeed(seed uint64, name string) uint64 {
+	if seed == 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	return seed ^ h.Sum64()
+}
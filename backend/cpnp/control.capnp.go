@@ -80,99 +80,270 @@ func (f Heartbeat_Future) Struct() (Heartbeat, error) {
 	return Heartbeat(p.Struct()), err
 }
 
-const schema_bc17d12a74fd5cc3 = "x\xda|\x94o\x88T\xd5\x1b\xc7\x9f\xe7\x9csgV" +
-	"tvf\xf6,\x88\xfa\xfb\x91\x98In\xf9\x8f\x84j" +
-	"\xa8\xd6\xdc\x15\xad\\\x9a\xb3F\xf4B\xc2\xb33\x87\x99" +
-	"qg\xe6\x8ew\xee\x8e;A\x18\xbd\xa8\x10\x8cT\xa4" +
-	"\x0c\"5\xd7\xd07&\xb4\xc9V\x86Z\x92\x88\x8a[" +
-	"\x18l\xb0\xb0\xbe\x10c+\xc2\x88\x8aLo\x9c3\xce" +
-	"\x9d\xdd\x99Y\xdf\xdd9|\xe6\xfb}\xfe|\xcfYy" +
-	"\x84\xaca\xabB\xef\x06\x81\x88\x97\xac\x80\xd7\xe5\x0d\xef" +
-	"^\xf5by\x17\x88\xd9\x88\xde\xf8\xed?7.X\xdf" +
-	"=\x0c\x16\x06\x01\x1e9A\x09\xf2S4\x08\xc0G\xe8" +
-	"v@o\xe2\xf9\x9b\xa7\x8f[\xbdoCtv\x03<" +
-	"\x8f\xcdG\xbe\x94i\xf8\x01\xd6\x09\xe8\xcd\x9d\xf8\xe2P" +
-	"\xf8\xc2\xcf{\x9a\xc0|\x1d\xfb\x8e\x0b\xc3\xf6\x18\xf6\x9d" +
-	"=\xdf\x1cQ\xad\xff\xdf\xdf\x8c\xcd\xb1I^6\xec\x80" +
-	"a\xbf\xfd\xed\xc2\xe2y\x9f\xbaC fY-\xde[" +
-	"\xd9\xf1;\xe2\x7f\x1d\xa3\x00\xc8\x87\xd8V~T\x93\x9b" +
-	"\x0e3\x8a\x80\x7f\x9dY\xf1\x91\xfb\xef\xc5cMD\xf7" +
-	"\xb1I~\xd0\x88~`D'\x1e*\xdf\x1f\xd9q\xf4" +
-	"+-\x8a\xd3D/\xb1\x9d\xfc\xaa\x11\xbdbD\xbd\x9b" +
-	"\xbbV\xccm\xdb2r\x16FgYw\xc2\xd3\xd83" +
-	"\xcc\xe1\xe7\x0c{\xba\xc2\xbe\xbc\xf7Cq\xea\x87\x9d\xe7" +
-	"\xb4\xec\xeai\xe8\x09\xb6\x95\x0f\x1b\xf4\x93\x0a\xda6\xd1" +
-	"\xfbK\xf9\x8d\xd2\xf9\xc6\xb6\x0e\xb2W\xf8\x90A\x0f\x18" +
-	"\xf4\xd6\xe1\xb1\xfe\xbd#C\xe7\xebVFt7\xbb\xd9" +
-	"\x1e\xbe\x9fU:\xbc\x01\xe8\x85N\x86N.x\xb2\xf7" +
-	"\x02\x88VD\xef\xeb\xcd\xb7\xdd\x8e\xd1\xb9_\x82!2" +
-	"\xd6Y\xbe\xcd2#\xb6\xf4\x0c\x9e\xbdq\xac\xf4\x1e\xdb" +
-	"w\xb9\xd9\x12vY\x9f\xf3}\x86\xddm\xd8\x03\xdbo" +
-	"=zq\xde\xc7\x97\xebr\xc3Ll,\x1d\x1b\x03\x8f" +
-	"\x18\xf8\x8f\xd7\xd8\x92\xfe\xb5\xbf\x7f\xdf,d|\xcc\x9a" +
-	"\xe4\xd7\x0d|\xcd:\x0e\xe8\x0dw\xb7.\xc1\xcfV^" +
-	"k\xdcD9\xf0:\x7f5\xa0\xe70\x180#\xbb\xf2" +
-	"\x84|j\xfc\xb97\xaf7)\x82g\x02?\xf2\x01\x0d" +
-	"\xf3m\x01\x1d]g\xb8\xf4\x13&~\xfd\xbb\xae93" +
-	"\xb4K\x81I>f\xd8\xab\x86\xdd\xbbp\xc5\xc4\xfb*" +
-	"\xf2\x8f.a\xe1\xb4\x12V\x05\x0f\xf1\xc7\x83\xba\x84\xd5" +
-	"A\x8a\xb0\xc8K\xc9\x9cZ\x9e\x90\x05\x9a/\xc4\xd6\xcb" +
-	"\x9cZ\xeb\xd82\x99\x90E\xb7\xcb\xce\xe7U\xc2\x858" +
-	"\xa2h\xa1\x0c\x80!@ti\x0c@,\xa6(V\x12" +
-	"DlG}\xb6\xac\x17@<LQ<F\xb0\xb3\x90" +
-	"\x95e\xe5`\xa4\xbac@\x8c\x00z\x89\x8a\x9a\x02L" +
-	"\"\x02A\xdd\xfd\x8c\xd6q\xa3\xd1c\xd3\x92\xd2\xee\xcc" +
-	"w\x0f-\x02\x10-\x14E;\xc1\xe0\xf6\xb4\xdd`S" +
-	"\x95$w%\xbb\xb2\x19\x95w\xbb\xd2\x12\xdd:\xa5\x8e" +
-	"\x9aR\xd8U\x83.\xce\x01\x82sf\x94X/\x9dp" +
-	"\x9fL\xa9\x19T\x16\x13\x0c\xa7e1\x8d\xad\x80q\x8a" +
-	"\x18\xa9e\x11P\x1fz)[\x8b\xe6\x0b\x10\x8e\xb92" +
-	"\x15G\xd4\x86\x0df\x9b\x94SRN<+\xc3e\xe5" +
-	"\x14\xeb\xcc\xd6\xd6\xccvT\xc6\\\xf4\xfd\xa6\xcca\xaa" +
-	"\x1b\xc6\x0a2\xd1/S\x0a\xc0\xb7\xac\x16r_,/" +
-	"s\xaa\xe18\x1cK\xda\x89\x86\xd3\xceX\xde\xae\xd4\x0d" +
-	"\xd4\xaf\x1a\xf3\x85\x98\xd9\x15:\xba\xd4\x88_\xaa\x9c\x0f" +
-	" 6S\x14i\x82\xd1jL\x94\x1e\xd6\x16\x8a\"K" +
-	"\x10I;\x12\x80h\xa6\x0d@$)\x8a\x02\xc1(\xc5" +
-	"v\xa4\x00\xd1\x9c>LS\x14.A\x9aIV7\x13" +
-	"\xd6\xe5V\x7f\xe0 2 \xc8\x00\xb1\\\xfd\xd2!s" +
-	"\x1d;\xbb\x9c\x98\x9ac\x1b\x94t\xdc>%+\x19\x9e" +
-	"a\xf7\x03\xf9\xcc Z@\xd0\x9a\xb2{4\xebp\xfa" +
-	"dgJuKW\xde\xe3\xffI\xe9J\x0c\x01\xc1P" +
-	"\x93DW\xd6i\x94R\xea\xe9D?\xdc#\xce2\xd1" +
-	"\x8f-@\xb0\xa5I\x06}\x1d?\x83s|\x91u\xfa" +
-	"F\xae\xa1(6\xeaY\xb3\xca\xac\x9f\xd1\xca\xdd\x14E" +
-	"\xbc6\xeb\x1e]\xf6\x06\x8a\xe2\x05\x82\x9d2g\x0f\xe4" +
-	"\xdd\xaaa\xb0\xa0\x1c\x0c\x00\xc1\x00`\xb8O\x16U\xad" +
-	"#?H\x99\\\xc1v\\\x98)\xb9\x95k\xd2c\x97" +
-	"\xa8J\xd6=\x19m\xb5'\xc3\x0f\xc32}\xf8 E" +
-	"\xb1\x9a\xe8]\x12 H\xcc.\xef~5\xe8\xfb\x8fC" +
-	"\xb8+-\xdd:\x87\x8ef\x0e\x1d5\x87i\xd1\xa9\xbb" +
-	"\xee~\x83\x89\x81\xa2k\xe7\xdc`\xb9P\xbd\x14\xff\x05" +
-	"\x00\x00\xff\xff\x9b\x8b%\x89"
+const schema_bc17d12a74fd5cc3 = "x\xda\xb4X}\x90[\xd5u\xbf\xe7=\xad\xef\xee\xda" +
+	"\xbb\xd2\xd5]\x8f\xad\xf5\xee\x08oc\x06\x8b\x1a{w" +
+	"\xd9\x124\xb8\xb2\xd7\xde16V\xb2W\xc2\x01\xa7\xc0" +
+	"\xf0Vz\x96\x1e+\xbd'?\xbd\xdd\xb5Z\xc0\x98q" +
+	"h\xa0qJ \x9e\xe0\xb6\xa46\x89\xd3\x98\xb6\xc3\xc7" +
+	"d\x09\xa6\x90\x01\x8a\xa9m>\x8aK \x98\x89g\xcc" +
+	"\x964\xb0\x84i\xa1LB\xf8R\xe7>]==I" +
+	"o\x17\xd3i\xff\xf8\xcdJ\xbaw\xef=\xe7\xdc\xdf\xf9" +
+	"\x9ds\xef\x9a\xd3m\xeb|\xfd\x1d\x97\x10$\xb1\xe3-" +
+	"\x0b\xca'\xcen\x9e\xed\xbc\xe5\x95\xbd\x88,\x84\xf2\x99" +
+	"O\x7f\xbbu\xd9\xa6\x8d\xd3\xa8\x050BtO\xeb\xfb" +
+	"t_+\x16\x88!(\x17\xce\x1e=\xff\xc8\x05\xcf\xde" +
+	"\x86\xc8B\xa96\x1b\x01}\xa8\xf5az\xb4\x15\x0b\xec" +
+	"FP\xfe\xc1\xea7_K\xfe6z\x07b\x0bAj" +
+	"\\\xb9\xa5\xedV\xda\xd6\x86\x05~\x8d\xa0\xfc\xceg?" +
+	":}\"\xbfb\x9f\x97\x1d\xef\xb6\xcd\xd0\xdf\xb7a\x01" +
+	"n\xc7\x86\xf2\xf4w\xfa\xbfV\xda\xc7\x17o\x9c>\xd8" +
+	"\xdb.\x01]\xd9\x8e\x05\xa6\x10\x94\xcf~\xf5\xbd'\x1f" +
+	"hI|\xdbc\xf5\xc1\xfd\xed\xdd@\x0f\xb7c\x01\xbe" +
+	"\xfc\x92\xb3\xfft\x9f\xff\xe4;w5./qk^" +
+	"h\xff7z\xba\x1d\x0b<\x80\xa0\xfc\xdc\x89\x1b\xde\xfb" +
+	"y!\xfa\xbd\xa6\xa8\xecY\xf8\x18\xbd}!\x16\xb8\x04" +
+	"A\xf9\xce\xbb\x9e\xf9\x91\xda\xd9{\xc0\xcbpz`\xe1" +
+	",=\xbc\x10\x0b\xf0\x957\xadZ:i\x19\x97}\xdf" +
+	"+*\xca\xa2\xd7i~\x11\x16\xe0f\xdf\xdc1\xf1\xd1" +
+	"2\xf5\x91\x83\x0d!\xf7\xf1\xd9\x87\x17\xbdO\x1fZ\x84" +
+	"\x05x\xc4\xff\xe5?O~)\xf4\x13\xeb0bm-" +
+	"\xad\xe5o\xe6\xce|\xc6z\"\xa7\xb8\xd9\xf7v\xdc@" +
+	"\x0fu`\x8e\xe4\xc1\x0e\x19\x10|\xfa\xf5\x99\xd5\xf7^" +
+	"\xf8\xdca\x8f\xf0}\xa7\xa3\x1d\xaa\xb3\xe9\xa1\x0en\xc7" +
+	"\xd1\xc5\x8a\x12{\xfc\xd2\x1f7\xc5\xe3X\xc7}\xf4\x85" +
+	"\x0e,\x90AP\xde\xfb\xf2\xe2Pt\xc7\xe1#\x9e\xf1" +
+	"h\xe9<II'\x16\x98B\xf0\xbb\xa7V\xff\xc0\xfa" +
+	"\xe4\xf9\xfb=\xa21\xd19K\xf7tb\x01n\xc5s" +
+	"\x7f\xf14\xbe\xf3\xed\x97\x1e\xf4\xe4\xc8\xd1\xcev\xa0'" +
+	":\xb1\x00\xe7\xc8k\xb7\xb6\x9f\xff_\xddg\xa6=M" +
+	"\x19\xf2\xcf\xd0\xf5~,\xc0\xa7\x1f\xbb\xcehy\xfc\xca" +
+	"\xfb\x1fir\xf2\x88\xff>\xfa\x90\x1f\x0b\xf0S\xfc\xf6" +
+	"\xda\xb7/\xfe\xf7-\xdf{\xb4i\xea\x8a\xc0\xc3tU" +
+	"\x00\x0b\xf0x<\xb1o\xa7\xfc\xb3\x87~\xf7h\xfd\x81" +
+	"\xdb'\xa8\x06f\xe9\xce\x00\xae\x82\xb3\xfa\xc2\xd2\x1f\x04" +
+	"v\x1f\xf9\x19?A\xa8;\xc1m\x81;\xe8\xb5\x01\xcc" +
+	"\x91\xbc&\xc0O\xb0\xfc\xde\xbe\xd5K\x82\xd7\x1f}\x1a" +
+	"\x9djk\xf9\xcc_7;\x1e0)\x0b`\x8e\xe4h" +
+	"e\xf6uw\xff-{\xe2\xd5;\x8e\xf1\xa5/\xae\x9b" +
+	"\xbc>p\x03\x1d\x09`\x8e\xe4\xc6\xca\xe4w\xd7\xff\xfd" +
+	"u\x7f\xf2\xd6\x07\xcfz\xb1t(\xf0\x11]\x1f\xc0\x02" +
+	"\xfc\\\x82g\x13\xbf)\xdd6y\xbc\x99w\xa5\xc0\x9f" +
+	"\xd2\x9b\x02\x98#y\xa3\xbd\xf4\xc7?<=~\xf7\xd1" +
+	"\xc3\xc7\x1b4\xc4\xce\xc3|\xe0.:\x11\xc0\x1c\x83\x13" +
+	"\x8107\xa4\xe3\xa7\x1d?]\xb66q\x12\xb1N\x80" +
+	"\xf2?_\xf3\xa9\x159\xb5\xe4\xf1J\xf8\x0e\x91\xa7\xe9" +
+	"\x11\x82\x05\xb8!W\xa8\xff\xf8\xe8\xb3\xef=x\xd2;" +
+	"\xcb\xc9\xeb\xf44\xc1\x02<_z\xb3\xdf?\xe5\xfb\xf1" +
+	"\x8a\x17\x9bN\xf1T\xf0uz&\x88\x05\xbe\x8a\xa0\xbc" +
+	"\xe5\xd7\xf7O\xde\xe3\xdb\xff\xa2W@>\x0d>F[" +
+	"(\x16\xe0v\x1c\x9c\xfa\xf8\x92\xe7C\x7f\xf7\xa2G\xda" +
+	"\x0e\xae\xa4\x12\xd0!\x8a\x058\x9b\xde\xf9\xf0\xe3O^" +
+	"y\xf8\xfd\x7f\xf54\xfb\x0d:K\xdf\xa5X\x80O\xef" +
+	"\xfd\xe4\xbb\xfa\xdeg\x8c\x97\x1b\xa6\xdb1\xd9\xdf5C" +
+	"\x0fua\x01\xce\xea\x0fn\xf1\x9d?>\xfc\xbe=[" +
+	"n\xca\xc7\xc5\xb3\x94,\xc6\x1c\x83d\xf1%\x12\x8f\xf8" +
+	"\xd0\x93\xc6\xed\x13\x17\xfe\xc2Kr\xf6-\x99\xa5\x07\x96" +
+	"`\x01n\xcb\x8dW\xdd\xbc.\xf1\x1f\xc7\x7f\xe1\x99\x92" +
+	"k\x97\xb6\x03\x8d/\xc5\x02|\xfeU\xefl\xbc\xed\xba" +
+	"H\xe0\x97M!o\x0b=FI\x08\x0b\xf0r\xd3{" +
+	"\xdb\x030\xf2\xea\xae_z\xb9\xb9=t+\xbd6\x84" +
+	"\x05\xb8\x9b\xd3\x1b;\xcf\x87G\xd6\xbc\xd1\x9c9O\x85" +
+	"n\xa5\xc7B\x98#\xf9L\xc8\xa6\xf7g\xed\xf1\x99\x81" +
+	"\xce\xbe\x99\x86\xd3\x94\xf9\xd2\xd3\xa1\x8f\xe8S!,\xc0" +
+	"m~\xf4\xd0\xcd\x7f\xf6\xb5\x8f/\x9a\xf10dP\xed" +
+	"\xee\x03:\xd1\x8d\x05\xb8%/]\xa6\xfc\xf1\x99+\xfe" +
+	"\xfcW^v\x9f\xee~\x9d\xfe\xaa\x1b\x0b\xf0\xc5\x17\xdd" +
+	"\xf9\xf0\xab\xa9\xff\xde\xfa\xa1\x17Un_\xd6\x0e\xf4\xc0" +
+	"2,\xc0\xa7\x9b\xd3\x93oA\xea\xdd\x0f\x1b\xe3m\xaf" +
+	"\xbe\xbeg\x96\xc6{0\xc7`\xbc\xc7\xce\x9f\xbf~\xf9" +
+	"\xcd\x9e\xf4\x89\xc4\xef\x1bO\xdf\x9e?\xd1;C\xf7\xf4" +
+	"b\x8e\xc1=\xbd\x7f\xc9\xe7\xdf}\xde\xea\xb3\x7f\xa5\x06" +
+	">\xe2a<\xaf.\x8c+\xc3\xf7\xd1\xfe0\xe6H\xae" +
+	"\x09\xcb\x80\xfa\xca\x19%\xaf^\x94R\x0a\x92^\x88n" +
+	"R\xf2\xea\x86\x9c\xa6\xeaV\xb2\xa0\x86S\x96b\xa9\xa3" +
+	"\x00\xa3 1\x9f\xecC\xc8\x07\x08\x91\x8e(\xe9\xc0l" +
+	"\x91\x0cl\xa9\x04\xb1\x1dF.gL\x8d\x82\x04\x8b\x10" +
+	"\x078\xeb\x81^\x88\x8e*V6\x19\xb6\x14k\xa2(" +
+	"\xd6\xe9\x02\x09!2\x14%C\x18\x80\xf4\x0f\x93~\x0c" +
+	"\x12Y5LVa\x90\xc9\xca(Y\x89\xc1GV$" +
+	"\xc8J\x1c\xcb\x1b\x93\x9a\x9e\x19\x05i\xb7b\x9a\xda\xa4" +
+	"\x9a\xe6\x1f\xc7rFj\xdc\xfe\x18\xd3\x0d\xbe\xfe(H" +
+	"\xe5\x94\xa2\xa7\xd4\\NE\xc0\x07\xeaL\xd8l\xa9y" +
+	"$6\x0f8N(\xddD\xc1\xecz\x19XN\x02\x80" +
+	".\xe0?j\x11\xa2a\x96\x95\x81Y\x12\x10\x09*\xb6" +
+	"\xee\x0c\x92\x9d\x98\x15d`7J@d\xa9\x0bd\x84" +
+	"H)HJ\x98\xed\x92\x81\xed\x95@\xd6\xf8\xb6\xd0\x8a" +
+	"8\xc0?\xae\xe9iWD`\x17\xff\xe2C\x1c\x00%" +
+	"\xd7\x979b\x9fPu\xac\xe4=\"\x1fqE\xde\xaf" +
+	"\xdbS\x9a\xe3.\x8b\xb5\x86MCI\xa7\x94\xa2\xb5\xc1" +
+	"\xd0u5eU\x83\xd0\xea\xacgG\x9b] \x03\xfb" +
+	"r-\x08C\x09r)f_\x96\x81m\x94 V\xc8" +
+	")%\xd5\xe4\xdb\x04\xaa\x9a\x8f\xd0:@\x08\x02\x08\xca" +
+	"\xa9\xca\xca\"\xec\x00\x88c\x1eKF\xed\xd5\xe2\x86<" +
+	"\xe9\xe1\\_\xd5\xb9\x1e\x09\xf0T\xd6\x98kS\xef\x98" +
+	"m\xc8*`\x89E\x179\x8b\x8eD\xc8\x08f\x1be" +
+	"`\xa35\x0f\xe3\x11\x12\xc7l\xab\x0c\xec\x1a\xd71o" +
+	"\x8f\x92\xed\x98]-\x03KK\xe0\xb7\xd4]\x96+\xb8" +
+	"\xce\x91\xfak\xfd\x850\xc9\x8f f)fF\xb5\xe6" +
+	"J\x82M\x8a9\xa6\xc42j\xdcHW\xddn\xb5\xb7" +
+	"$\x09\xb2\x98g\x01\x89\x10\x82\xcb\xa6\x9a2\xf2\x85\x09" +
+	"\x0b\x01?V\xff\x94a\x8e\xbb\xb9\\\xef\xee&\xc5\xf4" +
+	"\x8f)\x19u\x1e\x8f\xd35\x8f\x95\x01\x17\xdb\x1d\x8f\xb5" +
+	"\x04\xc9c\x96\x93\x81\xed\x92\xc0\x9fU\x8a<\x95\xa0\x13" +
+	"\xc1\xa8\x0c\x10\xa8\x15K\xe1h'\x82\xf0\x98b\xa5\xb2" +
+	".\xaa\x97SY%\x97S\xf5L\xc5h\xe7\xe7F\xa3" +
+	"\x93\xaa9\xa9\x9a\x9b-U\xce\x17\x9bO~\xa0z\xf2" +
+	"\x7f(AX\xb3\xd4|\xb1\xce\x10\xe7\xc6R3d\x8e" +
+	"\x0dF\x15+\x9cMZj\xa19\xdf\x83\xee\x088\x09" +
+	"\x1f\xacKxI$|\x82L`f\xc9\xc0n\xe1\x09" +
+	"\xbf\xa0\x92\xf07E\xc9M\x98\xdd(\x03\xfb\x964_" +
+	"J\x9bj^\xd1tMG\x90qE$V\x14\"\xc8" +
+	")\xe4\xdc\xd6j\x14*g\x0c\xee\x8e^@\xfe\xa8\xa5" +
+	"d*\xf6s65%\x93Cw=\xa3^e\x98\xb9" +
+	"4B\xffK\xa1\xb0\x05\xdaT\x8b*\xd6SU\"\x05" +
+	"@\xaa\x0a\x03\x00Y\x11!+\xb8</\x8f\x90\xe5\\" +
+	"\x9e{\x13d9\x8e))K\x9b\xb49\xaa\xa5s\xf6" +
+	"_eJ\xe11(\x17'\x8a\x05UO7\xca0?" +
+	"\xa4\xcd\xfa\xa4\xaa[\x86Y\xb2\xf5\xd8C\x8b\"U-" +
+	"\xba\xb8\xc6\xdb\xfe\x01\xd2\x8f\xd9\x1a\x19\xd8eR\x93\xac" +
+	"\x86S\xc6\x84n}>\xebFs\x8a\xbf\xa4\x9a\x1e\xbc" +
+	"\x1bv\xf1nwE\xe9\xea\x98\xd7 >\x9d\xf3J\x1b" +
+	"\x0f\xa4\x9eR\xbd\\\xeb\xf6rm\x0b\x19\xc2\xec\xe2\x8a" +
+	"\xcc\xca\x9a\xdb\xb1r\xc1Y\x0bU\x08\xe3\\\xdc\xdc\x84" +
+	"\xf1t\xf6+\x86\x85\xb5\x94\xdalC\xc4K\xea#U" +
+	"\x1b\xd6In\x89s\xeeE\xb5\xed\x1a\x05\xb1\x8eD\x1b" +
+	"\xb2\x8au\x05\xb6\xff\xdd\xde\xb6\xc7&Q|\x80\xc49" +
+	"\x896G\xc9fN\xa2\x91\x04\xff+\x93\x91\x012\xc2" +
+	"k\xfc\xfaa\xb2\x1eC\x0bY;@\xd6bX@." +
+	"\x8d\x92Kqx\x8aS\x9a\x17\xf7L\xce\x18Sr\x9c" +
+	"F\x05\xd3\xd8\xa5\xe55\x0b\xd9\x89\x16.(\xa6\xc5?" +
+	"\xec\x9e\xcaj\xc5\x82]\x9c\xc2j\xde\xe0\x9d\x8a\x14+" +
+	"\x96\x8a\x96\x9ao\xec\x01\xbebXZ*\xac^Q3" +
+	"Q\xf0<\"x>,x\x9e\xe0\x7fe\xb2<J\x96" +
+	"c\xbf\xa6\xef0\xec}\x14S\xaf\xf4!e+k\x1a" +
+	"\x96U\xed3b\xe3\x9ahE\xe6P\xe9z\xea\x8d\xca" +
+	">'\xcd!ZPR\xe3J\xc6\xe1K%\xd7\xc5(" +
+	"\x0aGu\xa7\x01\xa8\x1f\xf1G\xd3F\xcaC\x1e\x1a\x94" +
+	"V\x9f\x8cU\xd2\xed\x0b\xab\xads\xf3w\xb3\xbe\xba{" +
+	",\xaa\x1b\x8e<!\xb9^Jx\x02\x81Y\xed\xf3\x9c" +
+	"\x0do\xea\xae\x0a\xe77]\xd2\xfb\x8d\x08\xf9\x06f{" +
+	"e`wJ\x00By\xf7\x05\xc9>\xcc\xbe%\x03\xbb" +
+	"\x87+/T\x94w\x7f\x90\xec\xc7\xec\xbb2\xb0\x83\x12" +
+	"\x10\x9f\xaf\x0b|\x08\x91{\xb7\x90C\x98\x1d\x94\x81=" +
+	"\xd8\x98@\x8d\x8a7\x8fZ\x9fS\xaa\xa5\x0c\xdd2\x8d" +
+	"\xdcE\x92\x1d\x84\xe8\xe5\xaabZc\xaab}\x9e\xf0" +
+	"N\xe8\x9a\xbds\x0b\xe2\x98+e\xaf2L9\x97n" +
+	"\xaeX\x11\xaf\x0eu\xc0\xb3C\x8d\xd6u\xa8\"l\xa5" +
+	"\x81j\x87zOs\x11\x08Oii+\xeb\x8aD," +
+	"\xabj\x99\xac\xe5\xfa%<\xa5\xe4r\xf5\xdcp.s" +
+	"sVb\x937%#z\xcaHk\xb2\x9e\xa9v'" +
+	"\xb6\x95\xbd\x11\xd2\xcb\x93-\xd4GB<\xd9\x16G\xc9" +
+	"b\\\xd5\x15\x9cUy\xa8bc\x9a\xae\x98\xa5\xc6\xfc" +
+	"\xadvP\x1b\x15K\xf9\x9c\x98\xa7\xed)\x12t \x8e" +
+	"f\xc1\xae\xc4\\\x18\xba>5\x8e\x9a;\xa8>W\xcf" +
+	"\xe8\xf05\x9e \x0c\xb3\xd1j\xd3(\x08\xbb=A\xae" +
+	"\xc5\xec\x1a\x19XV\x02\xac\xa4\xc6\xdd\xe5\xc8\xbb\x138" +
+	"\xd7\x96\xc9\xa9,\xfe\xcd\xb6\xa2\xcd\xd9\xe7]Y\xa3\x07" +
+	"\x1b\xae3\xb2\xda\xd9v\xbb;[\xcd^M\xf2\xe8\xab" +
+	"\x02\x08v\x17\x0b\xca\x94\xae\xba\xbbyy\xac\xe4\xa5\xfb" +
+	"\xf5B\x177&\xb1z\xa5\xd1\\v\x82\xae\xd2\xe7\xc4" +
+	"\xb2?\xe8*\xeb_\xe0v\xe4\x9c\x9d\xbb\xf5\xbd\xa0\xba" +
+	"\x19m\x83(m\x03\x9cl\x05\x19\x92]\xc07\xf4\xd9" +
+	"\x1bR\x02}\x94\x00N\x06\xf8H\x0f8\x82CC\x10" +
+	"\xa1!\xc0\xc9\xa5|\xe0K\xfc_d\xd9\xce\x1e\xba\x1c" +
+	"\"t9\xe0\xe4y|d\x0d\xd4\x84\x87\xae\x82-\xb4" +
+	"\x1fpr\x0d\x1fY\xc7GZZ\xba\xa0\x05!\xba\x16" +
+	"\"t-\xe0\xe4e|d+\x1fY\xd0\xd2\x05\x0b\x10" +
+	"\xa2\x9b\xe1\xeb4\x0e8\xb9\x95\x8f\\\xcdG\xb0\xdce" +
+	"\xbf\xddl\x83\x04\xdd\x0e8y5\x1fI\xf3\x91V_" +
+	"\x17\xb4\"D\x15\x18\xa6\x0a\xe0\xe4\xf5|$\xc7G\xda" +
+	"\xda\xbb\xa0\x0d!\xaaA\x94j\x80\x93Y>b\x81\x04" +
+	"1%\xdf\xd0\x0b\xe1Ja\x84\x05\x88\x03\xfccJQ" +
+	"u\xa5\x85\xd3\xed\xfbko9.\xc1S\xed\x04\xd63" +
+	"\x8e*:ol\xb5I\xfe\xbc}\x9d\xe1\xa3\xce;\xbb" +
+	"k\x89\xb4\xb6c\x87\x96\x9a\xc8!\xd9*\xb9\x0c\x99\x83" +
+	"\xfd\xbb\xed\x8b\x85Ztw\xccS\x9a\x9e\xae\xbc,`" +
+	"\xc41\x17\x1f\xe2\xc6\xa4\x9fg\xf2\xb9&r\xb0z\xfb" +
+	"\xbb\xda\x95\xc8\xdb\x82d\x1bfW\xca\xc0\xae\x97\x00\x17" +
+	"\xd5\x9d.K\xce\x81\xa1\xf5\xea\xc2\xfb\xa1\xcb\xb5\"/" +
+	"\xbf\x08}\xd1\xcc-p\xab\x02\x15\xab\xf2\x11\xf7\x0dm" +
+	"\xde;\xe8nU\xb7LM\xad\xd7k\xe7\xdd\xa8\xa6\xd7" +
+	"\xfe\xbca\xaa^\x97u\xb7\xce^n\xb3\xc3\xfd^\x13" +
+	"\x11\xef5Q\xf1^\x13\x15\xef5\x11\xf1^\xc3\x1b(" +
+	"\x7f1\xab\xf4\xdbMXV\x19\x18\xfa#\xf1i\xa8\x7f" +
+	"\x80_\x11\x8aYe\xb0\xf2|\xa3\x8c\xab\x03c\x8d\x0a" +
+	"\xbfA\xcd\xe5\xd0\xff\xb5|8\xbd\x96\x96/\x18\xa6\x85" +
+	"\xe6i\x9b\x1c\xb5\x8d%\xd4Z\xeb\xe5>\xb5nO2" +
+	"\xf5y\x92)\xe2\"S}k\x82\x8d\xdc|\x9d\xca\x1c" +
+	"\x84\x8a\x1b\x93\xea\x06\xc34\xd5\x94\x855C\xff\x7f\x96" +
+	"YG\xd2e5\xdd\x1c\x87\xe09$\x95(<\xdb\xfa" +
+	"\\q\xa8\x18 !\x0ea\x80\xf8\xd2\x90ns\xdfx" +
+	"\xcf5\xa9\x1c\xb3X\xd4\xb3\x1e\x0e\xb8\xeb\xe1\xfc/;" +
+	"c\xea\x0e\x91/m\x88\xc3u\xeb\x9cK\x95j\x85\x9b" +
+	"\x9b\xdc\xdc\x0eG\xce\xbd\x1d\x8eT\xdb\xe1\xbfq\xbd<" +
+	"\x1e\xe8&\x070\xbbG\x06\xf6C^\x95\xe4J;|" +
+	"(Zm\x87\xff\xa1\xb9\xdb\xfbb\xcfY\xf5\x8c\x9d\xe7" +
+	"qK\x12w?q,#:\xb6\x9c\x0bG\x8f\xe3\xf0" +
+	"t\x1f\x99\xc6\xec'2\xb0']\x0e?\x11!O`" +
+	"\xf6\xb8\x0c\xecx\xcd\xe1c\x11r\x0c\xb3gd`/" +
+	"\xb9\x1a\xd9\x17\xba\xc9\x0b\x98=/\x03{\x8d;\xdcZ" +
+	"q\xf8\x95\x08y\x05\xb3\x9f\xcb\xc0\xde\xe4%X\xb2K" +
+	"0y#B\xde\xc0\xec\xac\x0c\xec7\xbc\xfc\xcav\xf9" +
+	"%oG\xc9\xdb\x98\xbd%\x03\xfb\xa0\xa6\xee\xe2@\xfd" +
+	"\x96\x96W]]zc\xec\x1a\xae\x16\xf3F\xae1\xce" +
+	"^\xb1sD)5Q\xb4\x8c\xbc\x85K\x05\xd7E\xef" +
+	"\x7f\x06\x00DLlk"
 
 func RegisterSchema(reg *schemas.Registry) {
 	reg.Register(&schemas.Schema{
 		String: schema_bc17d12a74fd5cc3,
 		Nodes: []uint64{
+			0x84d5800eea49e0c9,
+			0x86c628a926b9e070,
+			0x8a3af653d8e42fa3,
+			0x8d256dc9d9a6feeb,
 			0x8d79563191b5ff43,
 			0x8e5205afc0f14fe0,
 			0x92ebca0fa2bbe017,
+			0x973a70d4f16ac9cb,
 			0x991d0e65a6c49290,
+			0x9e3c6f7476182d47,
+			0xa0b6651bfa750d7e,
 			0xa574b41924caefc7,
+			0xa5cb2b9d2fe2005a,
+			0xa839bc3f616115b9,
+			0xa9a5663a1915d384,
 			0xaaccfc7400a32fc1,
+			0xb0d0e99007c28bcb,
+			0xb5dd1af0260a82d8,
+			0xb6aa54bc056f5ec5,
+			0xb8974ae334e93d8e,
+			0xb8f7b1be03718dbd,
 			0xbea97f1023792be0,
 			0xc2b96012172f8df1,
 			0xc58ad6bd519f935e,
+			0xc6f4e85b5eab41ed,
 			0xc8768679ec52e012,
 			0xc8a5b9936b00d9a4,
 			0xca523d1bb70db70d,
+			0xcab0f1c6b8ad654b,
+			0xce25a804d19e681d,
 			0xce95049876aae74a,
 			0xcea719cc37fb77a0,
+			0xcff2b2d5fcfbf8eb,
+			0xd36fc4846e94fc1d,
 			0xd3f2426b260480f4,
+			0xd72b75896fc0350d,
+			0xd7c8e652407e577c,
+			0xdc102a5e8644eb57,
+			0xdc78d64501af861d,
 			0xe130b601260e44b5,
+			0xe2220e32e24d0afe,
+			0xe22efb567b7ea1b8,
 			0xe5874bdd3e613cd0,
+			0xf84cf363d6b2900c,
 			0xf8ed6301e876b572,
+			0xf952c9641ce4d39b,
 			0xfa10659ae02f2093,
 		},
 		Compressed: true,
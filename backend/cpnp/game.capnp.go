@@ -7,18 +7,72 @@ import (
 	text "capnproto.org/go/capnp/v3/encoding/text"
 )
 
+type Presence uint16
+
+// Presence_TypeID is the unique identifier for the type Presence.
+const Presence_TypeID = 0xa839bc3f616115b9
+
+// Values of Presence.
+const (
+	Presence_active    Presence = 0
+	Presence_idle      Presence = 1
+	Presence_away      Presence = 2
+	Presence_suspended Presence = 3
+)
+
+// String returns the enum's constant name.
+func (c Presence) String() string {
+	switch c {
+	case Presence_active:
+		return "active"
+	case Presence_idle:
+		return "idle"
+	case Presence_away:
+		return "away"
+	case Presence_suspended:
+		return "suspended"
+
+	default:
+		return ""
+	}
+}
+
+// PresenceFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func PresenceFromString(c string) Presence {
+	switch c {
+	case "active":
+		return Presence_active
+	case "idle":
+		return Presence_idle
+	case "away":
+		return Presence_away
+	case "suspended":
+		return Presence_suspended
+
+	default:
+		return 0
+	}
+}
+
+type Presence_List = capnp.EnumList[Presence]
+
+func NewPresence_List(s *capnp.Segment, sz int32) (Presence_List, error) {
+	return capnp.NewEnumList[Presence](s, sz)
+}
+
 type Player capnp.Struct
 
 // Player_TypeID is the unique identifier for the type Player.
 const Player_TypeID = 0xc8a5b9936b00d9a4
 
 func NewPlayer(s *capnp.Segment) (Player, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
 	return Player(st), err
 }
 
 func NewRootPlayer(s *capnp.Segment) (Player, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
 	return Player(st), err
 }
 
@@ -106,12 +160,20 @@ func (s Player) SetY(v int32) {
 	capnp.Struct(s).SetUint32(4, uint32(v))
 }
 
+func (s Player) Presence() Presence {
+	return Presence(capnp.Struct(s).Uint16(8))
+}
+
+func (s Player) SetPresence(v Presence) {
+	capnp.Struct(s).SetUint16(8, uint16(v))
+}
+
 // Player_List is a list of Player.
 type Player_List = capnp.StructList[Player]
 
 // NewPlayer creates a new list of Player.
 func NewPlayer_List(s *capnp.Segment, sz int32) (Player_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2}, sz)
 	return capnp.StructList[Player](l), err
 }
 
@@ -123,629 +185,3100 @@ func (f Player_Future) Struct() (Player, error) {
 	return Player(p.Struct()), err
 }
 
-type GameBroadcastConnect capnp.Struct
+type Item capnp.Struct
 
-// GameBroadcastConnect_TypeID is the unique identifier for the type GameBroadcastConnect.
-const GameBroadcastConnect_TypeID = 0x8d79563191b5ff43
+// Item_TypeID is the unique identifier for the type Item.
+const Item_TypeID = 0x8a3af653d8e42fa3
 
-func NewGameBroadcastConnect(s *capnp.Segment) (GameBroadcastConnect, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GameBroadcastConnect(st), err
+func NewItem(s *capnp.Segment) (Item, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Item(st), err
 }
 
-func NewRootGameBroadcastConnect(s *capnp.Segment) (GameBroadcastConnect, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GameBroadcastConnect(st), err
+func NewRootItem(s *capnp.Segment) (Item, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return Item(st), err
 }
 
-func ReadRootGameBroadcastConnect(msg *capnp.Message) (GameBroadcastConnect, error) {
+func ReadRootItem(msg *capnp.Message) (Item, error) {
 	root, err := msg.Root()
-	return GameBroadcastConnect(root.Struct()), err
+	return Item(root.Struct()), err
 }
 
-func (s GameBroadcastConnect) String() string {
-	str, _ := text.Marshal(0x8d79563191b5ff43, capnp.Struct(s))
+func (s Item) String() string {
+	str, _ := text.Marshal(0x8a3af653d8e42fa3, capnp.Struct(s))
 	return str
 }
 
-func (s GameBroadcastConnect) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s Item) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameBroadcastConnect) DecodeFromPtr(p capnp.Ptr) GameBroadcastConnect {
-	return GameBroadcastConnect(capnp.Struct{}.DecodeFromPtr(p))
+func (Item) DecodeFromPtr(p capnp.Ptr) Item {
+	return Item(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameBroadcastConnect) ToPtr() capnp.Ptr {
+func (s Item) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameBroadcastConnect) IsValid() bool {
+func (s Item) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameBroadcastConnect) Message() *capnp.Message {
+func (s Item) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameBroadcastConnect) Segment() *capnp.Segment {
+func (s Item) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameBroadcastConnect) Player() (Player, error) {
+func (s Item) Id() uint32 {
+	return capnp.Struct(s).Uint32(0)
+}
+
+func (s Item) SetId(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
+}
+
+func (s Item) Kind() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return Player(p.Struct()), err
+	return p.Text(), err
 }
 
-func (s GameBroadcastConnect) HasPlayer() bool {
+func (s Item) HasKind() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GameBroadcastConnect) SetPlayer(v Player) error {
+func (s Item) KindBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s Item) SetKind(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s Item) X() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s Item) SetX(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s Item) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(8))
+}
+
+func (s Item) SetY(v int32) {
+	capnp.Struct(s).SetUint32(8, uint32(v))
+}
+
+// Item_List is a list of Item.
+type Item_List = capnp.StructList[Item]
+
+// NewItem creates a new list of Item.
+func NewItem_List(s *capnp.Segment, sz int32) (Item_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return capnp.StructList[Item](l), err
+}
+
+// Item_Future is a wrapper for a Item promised by a client call.
+type Item_Future struct{ *capnp.Future }
+
+func (f Item_Future) Struct() (Item, error) {
+	p, err := f.Future.Ptr()
+	return Item(p.Struct()), err
+}
+
+type GameBroadcastItem capnp.Struct
+
+// GameBroadcastItem_TypeID is the unique identifier for the type GameBroadcastItem.
+const GameBroadcastItem_TypeID = 0xcff2b2d5fcfbf8eb
+
+func NewGameBroadcastItem(s *capnp.Segment) (GameBroadcastItem, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameBroadcastItem(st), err
+}
+
+func NewRootGameBroadcastItem(s *capnp.Segment) (GameBroadcastItem, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameBroadcastItem(st), err
+}
+
+func ReadRootGameBroadcastItem(msg *capnp.Message) (GameBroadcastItem, error) {
+	root, err := msg.Root()
+	return GameBroadcastItem(root.Struct()), err
+}
+
+func (s GameBroadcastItem) String() string {
+	str, _ := text.Marshal(0xcff2b2d5fcfbf8eb, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastItem) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastItem) DecodeFromPtr(p capnp.Ptr) GameBroadcastItem {
+	return GameBroadcastItem(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastItem) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastItem) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastItem) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastItem) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastItem) Item() (Item, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Item(p.Struct()), err
+}
+
+func (s GameBroadcastItem) HasItem() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastItem) SetItem(v Item) error {
 	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
 }
 
-// NewPlayer sets the player field to a newly
-// allocated Player struct, preferring placement in s's segment.
-func (s GameBroadcastConnect) NewPlayer() (Player, error) {
-	ss, err := NewPlayer(capnp.Struct(s).Segment())
+// NewItem sets the item field to a newly
+// allocated Item struct, preferring placement in s's segment.
+func (s GameBroadcastItem) NewItem() (Item, error) {
+	ss, err := NewItem(capnp.Struct(s).Segment())
 	if err != nil {
-		return Player{}, err
+		return Item{}, err
 	}
 	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
 	return ss, err
 }
 
-func (s GameBroadcastConnect) Connected() bool {
+func (s GameBroadcastItem) Spawned() bool {
 	return capnp.Struct(s).Bit(0)
 }
 
-func (s GameBroadcastConnect) SetConnected(v bool) {
+func (s GameBroadcastItem) SetSpawned(v bool) {
 	capnp.Struct(s).SetBit(0, v)
 }
 
-// GameBroadcastConnect_List is a list of GameBroadcastConnect.
-type GameBroadcastConnect_List = capnp.StructList[GameBroadcastConnect]
+func (s GameBroadcastItem) By() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
 
-// NewGameBroadcastConnect creates a new list of GameBroadcastConnect.
-func NewGameBroadcastConnect_List(s *capnp.Segment, sz int32) (GameBroadcastConnect_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
-	return capnp.StructList[GameBroadcastConnect](l), err
+func (s GameBroadcastItem) HasBy() bool {
+	return capnp.Struct(s).HasPtr(1)
 }
 
-// GameBroadcastConnect_Future is a wrapper for a GameBroadcastConnect promised by a client call.
-type GameBroadcastConnect_Future struct{ *capnp.Future }
+func (s GameBroadcastItem) ByBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
 
-func (f GameBroadcastConnect_Future) Struct() (GameBroadcastConnect, error) {
+func (s GameBroadcastItem) SetBy(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+// GameBroadcastItem_List is a list of GameBroadcastItem.
+type GameBroadcastItem_List = capnp.StructList[GameBroadcastItem]
+
+// NewGameBroadcastItem creates a new list of GameBroadcastItem.
+func NewGameBroadcastItem_List(s *capnp.Segment, sz int32) (GameBroadcastItem_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return capnp.StructList[GameBroadcastItem](l), err
+}
+
+// GameBroadcastItem_Future is a wrapper for a GameBroadcastItem promised by a client call.
+type GameBroadcastItem_Future struct{ *capnp.Future }
+
+func (f GameBroadcastItem_Future) Struct() (GameBroadcastItem, error) {
 	p, err := f.Future.Ptr()
-	return GameBroadcastConnect(p.Struct()), err
+	return GameBroadcastItem(p.Struct()), err
 }
-func (p GameBroadcastConnect_Future) Player() Player_Future {
-	return Player_Future{Future: p.Future.Field(0, nil)}
+func (p GameBroadcastItem_Future) Item() Item_Future {
+	return Item_Future{Future: p.Future.Field(0, nil)}
 }
 
-type GameBroadcastChat capnp.Struct
+type GameServerItems capnp.Struct
 
-// GameBroadcastChat_TypeID is the unique identifier for the type GameBroadcastChat.
-const GameBroadcastChat_TypeID = 0xf8ed6301e876b572
+// GameServerItems_TypeID is the unique identifier for the type GameServerItems.
+const GameServerItems_TypeID = 0x9e3c6f7476182d47
 
-func NewGameBroadcastChat(s *capnp.Segment) (GameBroadcastChat, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return GameBroadcastChat(st), err
+func NewGameServerItems(s *capnp.Segment) (GameServerItems, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerItems(st), err
 }
 
-func NewRootGameBroadcastChat(s *capnp.Segment) (GameBroadcastChat, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
-	return GameBroadcastChat(st), err
+func NewRootGameServerItems(s *capnp.Segment) (GameServerItems, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerItems(st), err
 }
 
-func ReadRootGameBroadcastChat(msg *capnp.Message) (GameBroadcastChat, error) {
+func ReadRootGameServerItems(msg *capnp.Message) (GameServerItems, error) {
 	root, err := msg.Root()
-	return GameBroadcastChat(root.Struct()), err
+	return GameServerItems(root.Struct()), err
 }
 
-func (s GameBroadcastChat) String() string {
-	str, _ := text.Marshal(0xf8ed6301e876b572, capnp.Struct(s))
+func (s GameServerItems) String() string {
+	str, _ := text.Marshal(0x9e3c6f7476182d47, capnp.Struct(s))
 	return str
 }
 
-func (s GameBroadcastChat) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameServerItems) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameBroadcastChat) DecodeFromPtr(p capnp.Ptr) GameBroadcastChat {
-	return GameBroadcastChat(capnp.Struct{}.DecodeFromPtr(p))
+func (GameServerItems) DecodeFromPtr(p capnp.Ptr) GameServerItems {
+	return GameServerItems(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameBroadcastChat) ToPtr() capnp.Ptr {
+func (s GameServerItems) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameBroadcastChat) IsValid() bool {
+func (s GameServerItems) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameBroadcastChat) Message() *capnp.Message {
+func (s GameServerItems) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameBroadcastChat) Segment() *capnp.Segment {
+func (s GameServerItems) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameBroadcastChat) Name() (string, error) {
+func (s GameServerItems) Items() (Item_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Item_List(p.List()), err
+}
+
+func (s GameServerItems) HasItems() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerItems) SetItems(v Item_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewItems sets the items field to a newly
+// allocated Item_List, preferring placement in s's segment.
+func (s GameServerItems) NewItems(n int32) (Item_List, error) {
+	l, err := NewItem_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Item_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// GameServerItems_List is a list of GameServerItems.
+type GameServerItems_List = capnp.StructList[GameServerItems]
+
+// NewGameServerItems creates a new list of GameServerItems.
+func NewGameServerItems_List(s *capnp.Segment, sz int32) (GameServerItems_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerItems](l), err
+}
+
+// GameServerItems_Future is a wrapper for a GameServerItems promised by a client call.
+type GameServerItems_Future struct{ *capnp.Future }
+
+func (f GameServerItems_Future) Struct() (GameServerItems, error) {
+	p, err := f.Future.Ptr()
+	return GameServerItems(p.Struct()), err
+}
+
+type InventoryItem capnp.Struct
+
+// InventoryItem_TypeID is the unique identifier for the type InventoryItem.
+const InventoryItem_TypeID = 0xa9a5663a1915d384
+
+func NewInventoryItem(s *capnp.Segment) (InventoryItem, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return InventoryItem(st), err
+}
+
+func NewRootInventoryItem(s *capnp.Segment) (InventoryItem, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return InventoryItem(st), err
+}
+
+func ReadRootInventoryItem(msg *capnp.Message) (InventoryItem, error) {
+	root, err := msg.Root()
+	return InventoryItem(root.Struct()), err
+}
+
+func (s InventoryItem) String() string {
+	str, _ := text.Marshal(0xa9a5663a1915d384, capnp.Struct(s))
+	return str
+}
+
+func (s InventoryItem) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (InventoryItem) DecodeFromPtr(p capnp.Ptr) InventoryItem {
+	return InventoryItem(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s InventoryItem) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s InventoryItem) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s InventoryItem) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s InventoryItem) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s InventoryItem) Kind() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.Text(), err
 }
 
-func (s GameBroadcastChat) HasName() bool {
+func (s InventoryItem) HasKind() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GameBroadcastChat) NameBytes() ([]byte, error) {
+func (s InventoryItem) KindBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
 	return p.TextBytes(), err
 }
 
-func (s GameBroadcastChat) SetName(v string) error {
+func (s InventoryItem) SetKind(v string) error {
 	return capnp.Struct(s).SetText(0, v)
 }
 
-func (s GameBroadcastChat) Text() (string, error) {
-	p, err := capnp.Struct(s).Ptr(1)
-	return p.Text(), err
+func (s InventoryItem) Count() uint32 {
+	return capnp.Struct(s).Uint32(0)
 }
 
-func (s GameBroadcastChat) HasText() bool {
-	return capnp.Struct(s).HasPtr(1)
+func (s InventoryItem) SetCount(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
 }
 
-func (s GameBroadcastChat) TextBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(1)
-	return p.TextBytes(), err
+// InventoryItem_List is a list of InventoryItem.
+type InventoryItem_List = capnp.StructList[InventoryItem]
+
+// NewInventoryItem creates a new list of InventoryItem.
+func NewInventoryItem_List(s *capnp.Segment, sz int32) (InventoryItem_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[InventoryItem](l), err
 }
 
-func (s GameBroadcastChat) SetText(v string) error {
-	return capnp.Struct(s).SetText(1, v)
+// InventoryItem_Future is a wrapper for a InventoryItem promised by a client call.
+type InventoryItem_Future struct{ *capnp.Future }
+
+func (f InventoryItem_Future) Struct() (InventoryItem, error) {
+	p, err := f.Future.Ptr()
+	return InventoryItem(p.Struct()), err
 }
 
-// GameBroadcastChat_List is a list of GameBroadcastChat.
-type GameBroadcastChat_List = capnp.StructList[GameBroadcastChat]
+type GameServerInventory capnp.Struct
 
-// NewGameBroadcastChat creates a new list of GameBroadcastChat.
-func NewGameBroadcastChat_List(s *capnp.Segment, sz int32) (GameBroadcastChat_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
-	return capnp.StructList[GameBroadcastChat](l), err
+// GameServerInventory_TypeID is the unique identifier for the type GameServerInventory.
+const GameServerInventory_TypeID = 0xc6f4e85b5eab41ed
+
+func NewGameServerInventory(s *capnp.Segment) (GameServerInventory, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerInventory(st), err
 }
 
-// GameBroadcastChat_Future is a wrapper for a GameBroadcastChat promised by a client call.
-type GameBroadcastChat_Future struct{ *capnp.Future }
+func NewRootGameServerInventory(s *capnp.Segment) (GameServerInventory, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerInventory(st), err
+}
 
-func (f GameBroadcastChat_Future) Struct() (GameBroadcastChat, error) {
+func ReadRootGameServerInventory(msg *capnp.Message) (GameServerInventory, error) {
+	root, err := msg.Root()
+	return GameServerInventory(root.Struct()), err
+}
+
+func (s GameServerInventory) String() string {
+	str, _ := text.Marshal(0xc6f4e85b5eab41ed, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerInventory) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerInventory) DecodeFromPtr(p capnp.Ptr) GameServerInventory {
+	return GameServerInventory(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerInventory) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerInventory) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerInventory) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerInventory) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerInventory) Items() (InventoryItem_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return InventoryItem_List(p.List()), err
+}
+
+func (s GameServerInventory) HasItems() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerInventory) SetItems(v InventoryItem_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewItems sets the items field to a newly
+// allocated InventoryItem_List, preferring placement in s's segment.
+func (s GameServerInventory) NewItems(n int32) (InventoryItem_List, error) {
+	l, err := NewInventoryItem_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return InventoryItem_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// GameServerInventory_List is a list of GameServerInventory.
+type GameServerInventory_List = capnp.StructList[GameServerInventory]
+
+// NewGameServerInventory creates a new list of GameServerInventory.
+func NewGameServerInventory_List(s *capnp.Segment, sz int32) (GameServerInventory_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerInventory](l), err
+}
+
+// GameServerInventory_Future is a wrapper for a GameServerInventory promised by a client call.
+type GameServerInventory_Future struct{ *capnp.Future }
+
+func (f GameServerInventory_Future) Struct() (GameServerInventory, error) {
+	p, err := f.Future.Ptr()
+	return GameServerInventory(p.Struct()), err
+}
+
+type GameBroadcastPresence capnp.Struct
+
+// GameBroadcastPresence_TypeID is the unique identifier for the type GameBroadcastPresence.
+const GameBroadcastPresence_TypeID = 0xb0d0e99007c28bcb
+
+func NewGameBroadcastPresence(s *capnp.Segment) (GameBroadcastPresence, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameBroadcastPresence(st), err
+}
+
+func NewRootGameBroadcastPresence(s *capnp.Segment) (GameBroadcastPresence, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameBroadcastPresence(st), err
+}
+
+func ReadRootGameBroadcastPresence(msg *capnp.Message) (GameBroadcastPresence, error) {
+	root, err := msg.Root()
+	return GameBroadcastPresence(root.Struct()), err
+}
+
+func (s GameBroadcastPresence) String() string {
+	str, _ := text.Marshal(0xb0d0e99007c28bcb, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastPresence) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastPresence) DecodeFromPtr(p capnp.Ptr) GameBroadcastPresence {
+	return GameBroadcastPresence(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastPresence) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastPresence) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastPresence) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastPresence) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastPresence) Id() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameBroadcastPresence) HasId() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastPresence) IdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastPresence) SetId(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s GameBroadcastPresence) Presence() Presence {
+	return Presence(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameBroadcastPresence) SetPresence(v Presence) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+// GameBroadcastPresence_List is a list of GameBroadcastPresence.
+type GameBroadcastPresence_List = capnp.StructList[GameBroadcastPresence]
+
+// NewGameBroadcastPresence creates a new list of GameBroadcastPresence.
+func NewGameBroadcastPresence_List(s *capnp.Segment, sz int32) (GameBroadcastPresence_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[GameBroadcastPresence](l), err
+}
+
+// GameBroadcastPresence_Future is a wrapper for a GameBroadcastPresence promised by a client call.
+type GameBroadcastPresence_Future struct{ *capnp.Future }
+
+func (f GameBroadcastPresence_Future) Struct() (GameBroadcastPresence, error) {
+	p, err := f.Future.Ptr()
+	return GameBroadcastPresence(p.Struct()), err
+}
+
+type GameBroadcastRename capnp.Struct
+
+// GameBroadcastRename_TypeID is the unique identifier for the type GameBroadcastRename.
+const GameBroadcastRename_TypeID = 0xe2220e32e24d0afe
+
+func NewGameBroadcastRename(s *capnp.Segment) (GameBroadcastRename, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return GameBroadcastRename(st), err
+}
+
+func NewRootGameBroadcastRename(s *capnp.Segment) (GameBroadcastRename, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return GameBroadcastRename(st), err
+}
+
+func ReadRootGameBroadcastRename(msg *capnp.Message) (GameBroadcastRename, error) {
+	root, err := msg.Root()
+	return GameBroadcastRename(root.Struct()), err
+}
+
+func (s GameBroadcastRename) String() string {
+	str, _ := text.Marshal(0xe2220e32e24d0afe, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastRename) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastRename) DecodeFromPtr(p capnp.Ptr) GameBroadcastRename {
+	return GameBroadcastRename(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastRename) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastRename) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastRename) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastRename) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastRename) Id() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameBroadcastRename) HasId() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastRename) IdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastRename) SetId(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s GameBroadcastRename) Old() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s GameBroadcastRename) HasOld() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s GameBroadcastRename) OldBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastRename) SetOld(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s GameBroadcastRename) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s GameBroadcastRename) HasName() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s GameBroadcastRename) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastRename) SetName(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+// GameBroadcastRename_List is a list of GameBroadcastRename.
+type GameBroadcastRename_List = capnp.StructList[GameBroadcastRename]
+
+// NewGameBroadcastRename creates a new list of GameBroadcastRename.
+func NewGameBroadcastRename_List(s *capnp.Segment, sz int32) (GameBroadcastRename_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[GameBroadcastRename](l), err
+}
+
+// GameBroadcastRename_Future is a wrapper for a GameBroadcastRename promised by a client call.
+type GameBroadcastRename_Future struct{ *capnp.Future }
+
+func (f GameBroadcastRename_Future) Struct() (GameBroadcastRename, error) {
+	p, err := f.Future.Ptr()
+	return GameBroadcastRename(p.Struct()), err
+}
+
+type GameBroadcastConnect capnp.Struct
+
+// GameBroadcastConnect_TypeID is the unique identifier for the type GameBroadcastConnect.
+const GameBroadcastConnect_TypeID = 0x8d79563191b5ff43
+
+func NewGameBroadcastConnect(s *capnp.Segment) (GameBroadcastConnect, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameBroadcastConnect(st), err
+}
+
+func NewRootGameBroadcastConnect(s *capnp.Segment) (GameBroadcastConnect, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameBroadcastConnect(st), err
+}
+
+func ReadRootGameBroadcastConnect(msg *capnp.Message) (GameBroadcastConnect, error) {
+	root, err := msg.Root()
+	return GameBroadcastConnect(root.Struct()), err
+}
+
+func (s GameBroadcastConnect) String() string {
+	str, _ := text.Marshal(0x8d79563191b5ff43, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastConnect) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastConnect) DecodeFromPtr(p capnp.Ptr) GameBroadcastConnect {
+	return GameBroadcastConnect(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastConnect) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastConnect) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastConnect) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastConnect) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastConnect) Player() (Player, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Player(p.Struct()), err
+}
+
+func (s GameBroadcastConnect) HasPlayer() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastConnect) SetPlayer(v Player) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewPlayer sets the player field to a newly
+// allocated Player struct, preferring placement in s's segment.
+func (s GameBroadcastConnect) NewPlayer() (Player, error) {
+	ss, err := NewPlayer(capnp.Struct(s).Segment())
+	if err != nil {
+		return Player{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s GameBroadcastConnect) Connected() bool {
+	return capnp.Struct(s).Bit(0)
+}
+
+func (s GameBroadcastConnect) SetConnected(v bool) {
+	capnp.Struct(s).SetBit(0, v)
+}
+
+// GameBroadcastConnect_List is a list of GameBroadcastConnect.
+type GameBroadcastConnect_List = capnp.StructList[GameBroadcastConnect]
+
+// NewGameBroadcastConnect creates a new list of GameBroadcastConnect.
+func NewGameBroadcastConnect_List(s *capnp.Segment, sz int32) (GameBroadcastConnect_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[GameBroadcastConnect](l), err
+}
+
+// GameBroadcastConnect_Future is a wrapper for a GameBroadcastConnect promised by a client call.
+type GameBroadcastConnect_Future struct{ *capnp.Future }
+
+func (f GameBroadcastConnect_Future) Struct() (GameBroadcastConnect, error) {
+	p, err := f.Future.Ptr()
+	return GameBroadcastConnect(p.Struct()), err
+}
+func (p GameBroadcastConnect_Future) Player() Player_Future {
+	return Player_Future{Future: p.Future.Field(0, nil)}
+}
+
+type ChatKind uint16
+
+// ChatKind_TypeID is the unique identifier for the type ChatKind.
+const ChatKind_TypeID = 0xb6aa54bc056f5ec5
+
+// Values of ChatKind.
+const (
+	ChatKind_world     ChatKind = 0
+	ChatKind_global    ChatKind = 1
+	ChatKind_proximity ChatKind = 2
+	ChatKind_party     ChatKind = 3
+	ChatKind_whisper   ChatKind = 4
+	ChatKind_emote     ChatKind = 5
+	ChatKind_system    ChatKind = 6
+)
+
+// String returns the enum's constant name.
+func (c ChatKind) String() string {
+	switch c {
+	case ChatKind_world:
+		return "world"
+	case ChatKind_global:
+		return "global"
+	case ChatKind_proximity:
+		return "proximity"
+	case ChatKind_party:
+		return "party"
+	case ChatKind_whisper:
+		return "whisper"
+	case ChatKind_emote:
+		return "emote"
+	case ChatKind_system:
+		return "system"
+
+	default:
+		return ""
+	}
+}
+
+// ChatKindFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func ChatKindFromString(c string) ChatKind {
+	switch c {
+	case "world":
+		return ChatKind_world
+	case "global":
+		return ChatKind_global
+	case "proximity":
+		return ChatKind_proximity
+	case "party":
+		return ChatKind_party
+	case "whisper":
+		return ChatKind_whisper
+	case "emote":
+		return ChatKind_emote
+	case "system":
+		return ChatKind_system
+
+	default:
+		return 0
+	}
+}
+
+type ChatKind_List = capnp.EnumList[ChatKind]
+
+func NewChatKind_List(s *capnp.Segment, sz int32) (ChatKind_List, error) {
+	return capnp.NewEnumList[ChatKind](s, sz)
+}
+
+type GameBroadcastChat capnp.Struct
+
+// GameBroadcastChat_TypeID is the unique identifier for the type GameBroadcastChat.
+const GameBroadcastChat_TypeID = 0xf8ed6301e876b572
+
+func NewGameBroadcastChat(s *capnp.Segment) (GameBroadcastChat, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return GameBroadcastChat(st), err
+}
+
+func NewRootGameBroadcastChat(s *capnp.Segment) (GameBroadcastChat, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return GameBroadcastChat(st), err
+}
+
+func ReadRootGameBroadcastChat(msg *capnp.Message) (GameBroadcastChat, error) {
+	root, err := msg.Root()
+	return GameBroadcastChat(root.Struct()), err
+}
+
+func (s GameBroadcastChat) String() string {
+	str, _ := text.Marshal(0xf8ed6301e876b572, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastChat) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastChat) DecodeFromPtr(p capnp.Ptr) GameBroadcastChat {
+	return GameBroadcastChat(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastChat) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastChat) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastChat) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastChat) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastChat) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameBroadcastChat) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastChat) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastChat) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s GameBroadcastChat) Text() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s GameBroadcastChat) HasText() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s GameBroadcastChat) TextBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastChat) SetText(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s GameBroadcastChat) Kind() ChatKind {
+	return ChatKind(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameBroadcastChat) SetKind(v ChatKind) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s GameBroadcastChat) Id() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s GameBroadcastChat) HasId() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s GameBroadcastChat) IdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastChat) SetId(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s GameBroadcastChat) Target() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s GameBroadcastChat) HasTarget() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s GameBroadcastChat) TargetBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s GameBroadcastChat) SetTarget(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+// GameBroadcastChat_List is a list of GameBroadcastChat.
+type GameBroadcastChat_List = capnp.StructList[GameBroadcastChat]
+
+// NewGameBroadcastChat creates a new list of GameBroadcastChat.
+func NewGameBroadcastChat_List(s *capnp.Segment, sz int32) (GameBroadcastChat_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return capnp.StructList[GameBroadcastChat](l), err
+}
+
+// GameBroadcastChat_Future is a wrapper for a GameBroadcastChat promised by a client call.
+type GameBroadcastChat_Future struct{ *capnp.Future }
+
+func (f GameBroadcastChat_Future) Struct() (GameBroadcastChat, error) {
+	p, err := f.Future.Ptr()
+	return GameBroadcastChat(p.Struct()), err
+}
+
+type GameBroadcastPlayerMove capnp.Struct
+
+// GameBroadcastPlayerMove_TypeID is the unique identifier for the type GameBroadcastPlayerMove.
+const GameBroadcastPlayerMove_TypeID = 0x8e5205afc0f14fe0
+
+func NewGameBroadcastPlayerMove(s *capnp.Segment) (GameBroadcastPlayerMove, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameBroadcastPlayerMove(st), err
+}
+
+func NewRootGameBroadcastPlayerMove(s *capnp.Segment) (GameBroadcastPlayerMove, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameBroadcastPlayerMove(st), err
+}
+
+func ReadRootGameBroadcastPlayerMove(msg *capnp.Message) (GameBroadcastPlayerMove, error) {
+	root, err := msg.Root()
+	return GameBroadcastPlayerMove(root.Struct()), err
+}
+
+func (s GameBroadcastPlayerMove) String() string {
+	str, _ := text.Marshal(0x8e5205afc0f14fe0, capnp.Struct(s))
+	return str
+}
+
+func (s GameBroadcastPlayerMove) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameBroadcastPlayerMove) DecodeFromPtr(p capnp.Ptr) GameBroadcastPlayerMove {
+	return GameBroadcastPlayerMove(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameBroadcastPlayerMove) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameBroadcastPlayerMove) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameBroadcastPlayerMove) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameBroadcastPlayerMove) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameBroadcastPlayerMove) Who() (Player, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Player(p.Struct()), err
+}
+
+func (s GameBroadcastPlayerMove) HasWho() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameBroadcastPlayerMove) SetWho(v Player) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewWho sets the who field to a newly
+// allocated Player struct, preferring placement in s's segment.
+func (s GameBroadcastPlayerMove) NewWho() (Player, error) {
+	ss, err := NewPlayer(capnp.Struct(s).Segment())
+	if err != nil {
+		return Player{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// GameBroadcastPlayerMove_List is a list of GameBroadcastPlayerMove.
+type GameBroadcastPlayerMove_List = capnp.StructList[GameBroadcastPlayerMove]
+
+// NewGameBroadcastPlayerMove creates a new list of GameBroadcastPlayerMove.
+func NewGameBroadcastPlayerMove_List(s *capnp.Segment, sz int32) (GameBroadcastPlayerMove_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[GameBroadcastPlayerMove](l), err
+}
+
+// GameBroadcastPlayerMove_Future is a wrapper for a GameBroadcastPlayerMove promised by a client call.
+type GameBroadcastPlayerMove_Future struct{ *capnp.Future }
+
+func (f GameBroadcastPlayerMove_Future) Struct() (GameBroadcastPlayerMove, error) {
+	p, err := f.Future.Ptr()
+	return GameBroadcastPlayerMove(p.Struct()), err
+}
+func (p GameBroadcastPlayerMove_Future) Who() Player_Future {
+	return Player_Future{Future: p.Future.Field(0, nil)}
+}
+
+type GameServerGarbage capnp.Struct
+
+// GameServerGarbage_TypeID is the unique identifier for the type GameServerGarbage.
+const GameServerGarbage_TypeID = 0xd3f2426b260480f4
+
+func NewGameServerGarbage(s *capnp.Segment) (GameServerGarbage, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return GameServerGarbage(st), err
+}
+
+func NewRootGameServerGarbage(s *capnp.Segment) (GameServerGarbage, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1})
+	return GameServerGarbage(st), err
+}
+
+func ReadRootGameServerGarbage(msg *capnp.Message) (GameServerGarbage, error) {
+	root, err := msg.Root()
+	return GameServerGarbage(root.Struct()), err
+}
+
+func (s GameServerGarbage) String() string {
+	str, _ := text.Marshal(0xd3f2426b260480f4, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerGarbage) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerGarbage) DecodeFromPtr(p capnp.Ptr) GameServerGarbage {
+	return GameServerGarbage(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerGarbage) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerGarbage) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerGarbage) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerGarbage) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerGarbage) Amount() uint32 {
+	return capnp.Struct(s).Uint32(0)
+}
+
+func (s GameServerGarbage) SetAmount(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
+}
+
+func (s GameServerGarbage) Per() uint8 {
+	return capnp.Struct(s).Uint8(4)
+}
+
+func (s GameServerGarbage) SetPer(v uint8) {
+	capnp.Struct(s).SetUint8(4, v)
+}
+
+func (s GameServerGarbage) Base() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s GameServerGarbage) HasBase() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerGarbage) SetBase(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s GameServerGarbage) Hash() GarbageHash {
+	return GarbageHash(capnp.Struct(s).Uint16(6))
+}
+
+func (s GameServerGarbage) SetHash(v GarbageHash) {
+	capnp.Struct(s).SetUint16(6, uint16(v))
+}
+
+func (s GameServerGarbage) Encoding() GarbageEncoding {
+	return GarbageEncoding(capnp.Struct(s).Uint16(8))
+}
+
+func (s GameServerGarbage) SetEncoding(v GarbageEncoding) {
+	capnp.Struct(s).SetUint16(8, uint16(v))
+}
+
+func (s GameServerGarbage) Mode() GarbageMode {
+	return GarbageMode(capnp.Struct(s).Uint16(10))
+}
+
+func (s GameServerGarbage) SetMode(v GarbageMode) {
+	capnp.Struct(s).SetUint16(10, uint16(v))
+}
+
+func (s GameServerGarbage) Difficulty() uint8 {
+	return capnp.Struct(s).Uint8(5)
+}
+
+func (s GameServerGarbage) SetDifficulty(v uint8) {
+	capnp.Struct(s).SetUint8(5, v)
+}
+
+func (s GameServerGarbage) Challenge() uint32 {
+	return capnp.Struct(s).Uint32(12)
+}
+
+func (s GameServerGarbage) SetChallenge(v uint32) {
+	capnp.Struct(s).SetUint32(12, v)
+}
+
+func (s GameServerGarbage) Batches() uint32 {
+	return capnp.Struct(s).Uint32(16)
+}
+
+func (s GameServerGarbage) SetBatches(v uint32) {
+	capnp.Struct(s).SetUint32(16, v)
+}
+
+func (s GameServerGarbage) Window() uint16 {
+	return capnp.Struct(s).Uint16(20)
+}
+
+func (s GameServerGarbage) SetWindow(v uint16) {
+	capnp.Struct(s).SetUint16(20, v)
+}
+
+// GameServerGarbage_List is a list of GameServerGarbage.
+type GameServerGarbage_List = capnp.StructList[GameServerGarbage]
+
+// NewGameServerGarbage creates a new list of GameServerGarbage.
+func NewGameServerGarbage_List(s *capnp.Segment, sz int32) (GameServerGarbage_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerGarbage](l), err
+}
+
+// GameServerGarbage_Future is a wrapper for a GameServerGarbage promised by a client call.
+type GameServerGarbage_Future struct{ *capnp.Future }
+
+func (f GameServerGarbage_Future) Struct() (GameServerGarbage, error) {
+	p, err := f.Future.Ptr()
+	return GameServerGarbage(p.Struct()), err
+}
+
+type GarbageMode uint16
+
+// GarbageMode_TypeID is the unique identifier for the type GarbageMode.
+const GarbageMode_TypeID = 0x973a70d4f16ac9cb
+
+// Values of GarbageMode.
+const (
+	GarbageMode_recompute GarbageMode = 0
+	GarbageMode_work      GarbageMode = 1
+)
+
+// String returns the enum's constant name.
+func (c GarbageMode) String() string {
+	switch c {
+	case GarbageMode_recompute:
+		return "recompute"
+	case GarbageMode_work:
+		return "work"
+
+	default:
+		return ""
+	}
+}
+
+// GarbageModeFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func GarbageModeFromString(c string) GarbageMode {
+	switch c {
+	case "recompute":
+		return GarbageMode_recompute
+	case "work":
+		return GarbageMode_work
+
+	default:
+		return 0
+	}
+}
+
+type GarbageMode_List = capnp.EnumList[GarbageMode]
+
+func NewGarbageMode_List(s *capnp.Segment, sz int32) (GarbageMode_List, error) {
+	return capnp.NewEnumList[GarbageMode](s, sz)
+}
+
+type GarbageHash uint16
+
+// GarbageHash_TypeID is the unique identifier for the type GarbageHash.
+const GarbageHash_TypeID = 0xdc102a5e8644eb57
+
+// Values of GarbageHash.
+const (
+	GarbageHash_sha1    GarbageHash = 0
+	GarbageHash_sha256  GarbageHash = 1
+	GarbageHash_sha512  GarbageHash = 2
+	GarbageHash_sha3    GarbageHash = 3
+	GarbageHash_blake2b GarbageHash = 4
+)
+
+// String returns the enum's constant name.
+func (c GarbageHash) String() string {
+	switch c {
+	case GarbageHash_sha1:
+		return "sha1"
+	case GarbageHash_sha256:
+		return "sha256"
+	case GarbageHash_sha512:
+		return "sha512"
+	case GarbageHash_sha3:
+		return "sha3"
+	case GarbageHash_blake2b:
+		return "blake2b"
+
+	default:
+		return ""
+	}
+}
+
+// GarbageHashFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func GarbageHashFromString(c string) GarbageHash {
+	switch c {
+	case "sha1":
+		return GarbageHash_sha1
+	case "sha256":
+		return GarbageHash_sha256
+	case "sha512":
+		return GarbageHash_sha512
+	case "sha3":
+		return GarbageHash_sha3
+	case "blake2b":
+		return GarbageHash_blake2b
+
+	default:
+		return 0
+	}
+}
+
+type GarbageHash_List = capnp.EnumList[GarbageHash]
+
+func NewGarbageHash_List(s *capnp.Segment, sz int32) (GarbageHash_List, error) {
+	return capnp.NewEnumList[GarbageHash](s, sz)
+}
+
+type GarbageEncoding uint16
+
+// GarbageEncoding_TypeID is the unique identifier for the type GarbageEncoding.
+const GarbageEncoding_TypeID = 0xce25a804d19e681d
+
+// Values of GarbageEncoding.
+const (
+	GarbageEncoding_text   GarbageEncoding = 0
+	GarbageEncoding_hex    GarbageEncoding = 1
+	GarbageEncoding_binary GarbageEncoding = 2
+)
+
+// String returns the enum's constant name.
+func (c GarbageEncoding) String() string {
+	switch c {
+	case GarbageEncoding_text:
+		return "text"
+	case GarbageEncoding_hex:
+		return "hex"
+	case GarbageEncoding_binary:
+		return "binary"
+
+	default:
+		return ""
+	}
+}
+
+// GarbageEncodingFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func GarbageEncodingFromString(c string) GarbageEncoding {
+	switch c {
+	case "text":
+		return GarbageEncoding_text
+	case "hex":
+		return GarbageEncoding_hex
+	case "binary":
+		return GarbageEncoding_binary
+
+	default:
+		return 0
+	}
+}
+
+type GarbageEncoding_List = capnp.EnumList[GarbageEncoding]
+
+func NewGarbageEncoding_List(s *capnp.Segment, sz int32) (GarbageEncoding_List, error) {
+	return capnp.NewEnumList[GarbageEncoding](s, sz)
+}
+
+type GameServerGarbageAck capnp.Struct
+
+// GameServerGarbageAck_TypeID is the unique identifier for the type GameServerGarbageAck.
+const GameServerGarbageAck_TypeID = 0xcea719cc37fb77a0
+
+func NewGameServerGarbageAck(s *capnp.Segment) (GameServerGarbageAck, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerGarbageAck(st), err
+}
+
+func NewRootGameServerGarbageAck(s *capnp.Segment) (GameServerGarbageAck, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerGarbageAck(st), err
+}
+
+func ReadRootGameServerGarbageAck(msg *capnp.Message) (GameServerGarbageAck, error) {
+	root, err := msg.Root()
+	return GameServerGarbageAck(root.Struct()), err
+}
+
+func (s GameServerGarbageAck) String() string {
+	str, _ := text.Marshal(0xcea719cc37fb77a0, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerGarbageAck) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerGarbageAck) DecodeFromPtr(p capnp.Ptr) GameServerGarbageAck {
+	return GameServerGarbageAck(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerGarbageAck) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerGarbageAck) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerGarbageAck) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerGarbageAck) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerGarbageAck) Ack() uint32 {
+	return capnp.Struct(s).Uint32(0)
+}
+
+func (s GameServerGarbageAck) SetAck(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
+}
+
+func (s GameServerGarbageAck) Remaining() uint32 {
+	return capnp.Struct(s).Uint32(4)
+}
+
+func (s GameServerGarbageAck) SetRemaining(v uint32) {
+	capnp.Struct(s).SetUint32(4, v)
+}
+
+func (s GameServerGarbageAck) Challenge() uint32 {
+	return capnp.Struct(s).Uint32(8)
+}
+
+func (s GameServerGarbageAck) SetChallenge(v uint32) {
+	capnp.Struct(s).SetUint32(8, v)
+}
+
+// GameServerGarbageAck_List is a list of GameServerGarbageAck.
+type GameServerGarbageAck_List = capnp.StructList[GameServerGarbageAck]
+
+// NewGameServerGarbageAck creates a new list of GameServerGarbageAck.
+func NewGameServerGarbageAck_List(s *capnp.Segment, sz int32) (GameServerGarbageAck_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return capnp.StructList[GameServerGarbageAck](l), err
+}
+
+// GameServerGarbageAck_Future is a wrapper for a GameServerGarbageAck promised by a client call.
+type GameServerGarbageAck_Future struct{ *capnp.Future }
+
+func (f GameServerGarbageAck_Future) Struct() (GameServerGarbageAck, error) {
+	p, err := f.Future.Ptr()
+	return GameServerGarbageAck(p.Struct()), err
+}
+
+type Cell capnp.Struct
+
+// Cell_TypeID is the unique identifier for the type Cell.
+const Cell_TypeID = 0xdc78d64501af861d
+
+func NewCell(s *capnp.Segment) (Cell, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Cell(st), err
+}
+
+func NewRootCell(s *capnp.Segment) (Cell, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return Cell(st), err
+}
+
+func ReadRootCell(msg *capnp.Message) (Cell, error) {
+	root, err := msg.Root()
+	return Cell(root.Struct()), err
+}
+
+func (s Cell) String() string {
+	str, _ := text.Marshal(0xdc78d64501af861d, capnp.Struct(s))
+	return str
+}
+
+func (s Cell) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (Cell) DecodeFromPtr(p capnp.Ptr) Cell {
+	return Cell(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s Cell) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s Cell) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s Cell) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s Cell) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s Cell) X() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s Cell) SetX(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s Cell) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s Cell) SetY(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// Cell_List is a list of Cell.
+type Cell_List = capnp.StructList[Cell]
+
+// NewCell creates a new list of Cell.
+func NewCell_List(s *capnp.Segment, sz int32) (Cell_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[Cell](l), err
+}
+
+// Cell_Future is a wrapper for a Cell promised by a client call.
+type Cell_Future struct{ *capnp.Future }
+
+func (f Cell_Future) Struct() (Cell, error) {
+	p, err := f.Future.Ptr()
+	return Cell(p.Struct()), err
+}
+
+type GameServerWorld capnp.Struct
+
+// GameServerWorld_TypeID is the unique identifier for the type GameServerWorld.
+const GameServerWorld_TypeID = 0xcab0f1c6b8ad654b
+
+func NewGameServerWorld(s *capnp.Segment) (GameServerWorld, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameServerWorld(st), err
+}
+
+func NewRootGameServerWorld(s *capnp.Segment) (GameServerWorld, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameServerWorld(st), err
+}
+
+func ReadRootGameServerWorld(msg *capnp.Message) (GameServerWorld, error) {
+	root, err := msg.Root()
+	return GameServerWorld(root.Struct()), err
+}
+
+func (s GameServerWorld) String() string {
+	str, _ := text.Marshal(0xcab0f1c6b8ad654b, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerWorld) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerWorld) DecodeFromPtr(p capnp.Ptr) GameServerWorld {
+	return GameServerWorld(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerWorld) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerWorld) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerWorld) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerWorld) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerWorld) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameServerWorld) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerWorld) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameServerWorld) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s GameServerWorld) Width() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s GameServerWorld) SetWidth(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s GameServerWorld) Height() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s GameServerWorld) SetHeight(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s GameServerWorld) Walls() (Cell_List, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return Cell_List(p.List()), err
+}
+
+func (s GameServerWorld) HasWalls() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s GameServerWorld) SetWalls(v Cell_List) error {
+	return capnp.Struct(s).SetPtr(1, v.ToPtr())
+}
+
+// NewWalls sets the walls field to a newly
+// allocated Cell_List, preferring placement in s's segment.
+func (s GameServerWorld) NewWalls(n int32) (Cell_List, error) {
+	l, err := NewCell_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Cell_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(1, l.ToPtr())
+	return l, err
+}
+
+// GameServerWorld_List is a list of GameServerWorld.
+type GameServerWorld_List = capnp.StructList[GameServerWorld]
+
+// NewGameServerWorld creates a new list of GameServerWorld.
+func NewGameServerWorld_List(s *capnp.Segment, sz int32) (GameServerWorld_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return capnp.StructList[GameServerWorld](l), err
+}
+
+// GameServerWorld_Future is a wrapper for a GameServerWorld promised by a client call.
+type GameServerWorld_Future struct{ *capnp.Future }
+
+func (f GameServerWorld_Future) Struct() (GameServerWorld, error) {
+	p, err := f.Future.Ptr()
+	return GameServerWorld(p.Struct()), err
+}
+
+type GameServerPlayers capnp.Struct
+
+// GameServerPlayers_TypeID is the unique identifier for the type GameServerPlayers.
+const GameServerPlayers_TypeID = 0xaaccfc7400a32fc1
+
+func NewGameServerPlayers(s *capnp.Segment) (GameServerPlayers, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerPlayers(st), err
+}
+
+func NewRootGameServerPlayers(s *capnp.Segment) (GameServerPlayers, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameServerPlayers(st), err
+}
+
+func ReadRootGameServerPlayers(msg *capnp.Message) (GameServerPlayers, error) {
+	root, err := msg.Root()
+	return GameServerPlayers(root.Struct()), err
+}
+
+func (s GameServerPlayers) String() string {
+	str, _ := text.Marshal(0xaaccfc7400a32fc1, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerPlayers) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerPlayers) DecodeFromPtr(p capnp.Ptr) GameServerPlayers {
+	return GameServerPlayers(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerPlayers) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerPlayers) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerPlayers) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerPlayers) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerPlayers) Players() (Player_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return Player_List(p.List()), err
+}
+
+func (s GameServerPlayers) HasPlayers() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerPlayers) SetPlayers(v Player_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewPlayers sets the players field to a newly
+// allocated Player_List, preferring placement in s's segment.
+func (s GameServerPlayers) NewPlayers(n int32) (Player_List, error) {
+	l, err := NewPlayer_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return Player_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// GameServerPlayers_List is a list of GameServerPlayers.
+type GameServerPlayers_List = capnp.StructList[GameServerPlayers]
+
+// NewGameServerPlayers creates a new list of GameServerPlayers.
+func NewGameServerPlayers_List(s *capnp.Segment, sz int32) (GameServerPlayers_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerPlayers](l), err
+}
+
+// GameServerPlayers_Future is a wrapper for a GameServerPlayers promised by a client call.
+type GameServerPlayers_Future struct{ *capnp.Future }
+
+func (f GameServerPlayers_Future) Struct() (GameServerPlayers, error) {
+	p, err := f.Future.Ptr()
+	return GameServerPlayers(p.Struct()), err
+}
+
+type GameServerMoveCorrection capnp.Struct
+
+// GameServerMoveCorrection_TypeID is the unique identifier for the type GameServerMoveCorrection.
+const GameServerMoveCorrection_TypeID = 0xe22efb567b7ea1b8
+
+func NewGameServerMoveCorrection(s *capnp.Segment) (GameServerMoveCorrection, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return GameServerMoveCorrection(st), err
+}
+
+func NewRootGameServerMoveCorrection(s *capnp.Segment) (GameServerMoveCorrection, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return GameServerMoveCorrection(st), err
+}
+
+func ReadRootGameServerMoveCorrection(msg *capnp.Message) (GameServerMoveCorrection, error) {
+	root, err := msg.Root()
+	return GameServerMoveCorrection(root.Struct()), err
+}
+
+func (s GameServerMoveCorrection) String() string {
+	str, _ := text.Marshal(0xe22efb567b7ea1b8, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerMoveCorrection) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerMoveCorrection) DecodeFromPtr(p capnp.Ptr) GameServerMoveCorrection {
+	return GameServerMoveCorrection(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerMoveCorrection) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerMoveCorrection) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerMoveCorrection) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerMoveCorrection) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerMoveCorrection) X() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s GameServerMoveCorrection) SetX(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s GameServerMoveCorrection) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s GameServerMoveCorrection) SetY(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// GameServerMoveCorrection_List is a list of GameServerMoveCorrection.
+type GameServerMoveCorrection_List = capnp.StructList[GameServerMoveCorrection]
+
+// NewGameServerMoveCorrection creates a new list of GameServerMoveCorrection.
+func NewGameServerMoveCorrection_List(s *capnp.Segment, sz int32) (GameServerMoveCorrection_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[GameServerMoveCorrection](l), err
+}
+
+// GameServerMoveCorrection_Future is a wrapper for a GameServerMoveCorrection promised by a client call.
+type GameServerMoveCorrection_Future struct{ *capnp.Future }
+
+func (f GameServerMoveCorrection_Future) Struct() (GameServerMoveCorrection, error) {
+	p, err := f.Future.Ptr()
+	return GameServerMoveCorrection(p.Struct()), err
+}
+
+type GameServerMoveAck capnp.Struct
+
+// GameServerMoveAck_TypeID is the unique identifier for the type GameServerMoveAck.
+const GameServerMoveAck_TypeID = 0xd72b75896fc0350d
+
+func NewGameServerMoveAck(s *capnp.Segment) (GameServerMoveAck, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerMoveAck(st), err
+}
+
+func NewRootGameServerMoveAck(s *capnp.Segment) (GameServerMoveAck, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerMoveAck(st), err
+}
+
+func ReadRootGameServerMoveAck(msg *capnp.Message) (GameServerMoveAck, error) {
+	root, err := msg.Root()
+	return GameServerMoveAck(root.Struct()), err
+}
+
+func (s GameServerMoveAck) String() string {
+	str, _ := text.Marshal(0xd72b75896fc0350d, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerMoveAck) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerMoveAck) DecodeFromPtr(p capnp.Ptr) GameServerMoveAck {
+	return GameServerMoveAck(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerMoveAck) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerMoveAck) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerMoveAck) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerMoveAck) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerMoveAck) Seq() uint32 {
+	return capnp.Struct(s).Uint32(0)
+}
+
+func (s GameServerMoveAck) SetSeq(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
+}
+
+func (s GameServerMoveAck) X() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s GameServerMoveAck) SetX(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s GameServerMoveAck) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(8))
+}
+
+func (s GameServerMoveAck) SetY(v int32) {
+	capnp.Struct(s).SetUint32(8, uint32(v))
+}
+
+// GameServerMoveAck_List is a list of GameServerMoveAck.
+type GameServerMoveAck_List = capnp.StructList[GameServerMoveAck]
+
+// NewGameServerMoveAck creates a new list of GameServerMoveAck.
+func NewGameServerMoveAck_List(s *capnp.Segment, sz int32) (GameServerMoveAck_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return capnp.StructList[GameServerMoveAck](l), err
+}
+
+// GameServerMoveAck_Future is a wrapper for a GameServerMoveAck promised by a client call.
+type GameServerMoveAck_Future struct{ *capnp.Future }
+
+func (f GameServerMoveAck_Future) Struct() (GameServerMoveAck, error) {
+	p, err := f.Future.Ptr()
+	return GameServerMoveAck(p.Struct()), err
+}
+
+type PathStatus uint16
+
+// PathStatus_TypeID is the unique identifier for the type PathStatus.
+const PathStatus_TypeID = 0x86c628a926b9e070
+
+// Values of PathStatus.
+const (
+	PathStatus_moving    PathStatus = 0
+	PathStatus_arrived   PathStatus = 1
+	PathStatus_blocked   PathStatus = 2
+	PathStatus_noPath    PathStatus = 3
+	PathStatus_cancelled PathStatus = 4
+)
+
+// String returns the enum's constant name.
+func (c PathStatus) String() string {
+	switch c {
+	case PathStatus_moving:
+		return "moving"
+	case PathStatus_arrived:
+		return "arrived"
+	case PathStatus_blocked:
+		return "blocked"
+	case PathStatus_noPath:
+		return "noPath"
+	case PathStatus_cancelled:
+		return "cancelled"
+
+	default:
+		return ""
+	}
+}
+
+// PathStatusFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func PathStatusFromString(c string) PathStatus {
+	switch c {
+	case "moving":
+		return PathStatus_moving
+	case "arrived":
+		return PathStatus_arrived
+	case "blocked":
+		return PathStatus_blocked
+	case "noPath":
+		return PathStatus_noPath
+	case "cancelled":
+		return PathStatus_cancelled
+
+	default:
+		return 0
+	}
+}
+
+type PathStatus_List = capnp.EnumList[PathStatus]
+
+func NewPathStatus_List(s *capnp.Segment, sz int32) (PathStatus_List, error) {
+	return capnp.NewEnumList[PathStatus](s, sz)
+}
+
+type GameServerPathStep capnp.Struct
+
+// GameServerPathStep_TypeID is the unique identifier for the type GameServerPathStep.
+const GameServerPathStep_TypeID = 0xa0b6651bfa750d7e
+
+func NewGameServerPathStep(s *capnp.Segment) (GameServerPathStep, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerPathStep(st), err
+}
+
+func NewRootGameServerPathStep(s *capnp.Segment) (GameServerPathStep, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameServerPathStep(st), err
+}
+
+func ReadRootGameServerPathStep(msg *capnp.Message) (GameServerPathStep, error) {
+	root, err := msg.Root()
+	return GameServerPathStep(root.Struct()), err
+}
+
+func (s GameServerPathStep) String() string {
+	str, _ := text.Marshal(0xa0b6651bfa750d7e, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerPathStep) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerPathStep) DecodeFromPtr(p capnp.Ptr) GameServerPathStep {
+	return GameServerPathStep(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerPathStep) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerPathStep) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerPathStep) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerPathStep) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerPathStep) X() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s GameServerPathStep) SetX(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s GameServerPathStep) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s GameServerPathStep) SetY(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+func (s GameServerPathStep) Remaining() uint32 {
+	return capnp.Struct(s).Uint32(8)
+}
+
+func (s GameServerPathStep) SetRemaining(v uint32) {
+	capnp.Struct(s).SetUint32(8, v)
+}
+
+func (s GameServerPathStep) Status() PathStatus {
+	return PathStatus(capnp.Struct(s).Uint16(12))
+}
+
+func (s GameServerPathStep) SetStatus(v PathStatus) {
+	capnp.Struct(s).SetUint16(12, uint16(v))
+}
+
+// GameServerPathStep_List is a list of GameServerPathStep.
+type GameServerPathStep_List = capnp.StructList[GameServerPathStep]
+
+// NewGameServerPathStep creates a new list of GameServerPathStep.
+func NewGameServerPathStep_List(s *capnp.Segment, sz int32) (GameServerPathStep_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return capnp.StructList[GameServerPathStep](l), err
+}
+
+// GameServerPathStep_Future is a wrapper for a GameServerPathStep promised by a client call.
+type GameServerPathStep_Future struct{ *capnp.Future }
+
+func (f GameServerPathStep_Future) Struct() (GameServerPathStep, error) {
+	p, err := f.Future.Ptr()
+	return GameServerPathStep(p.Struct()), err
+}
+
+type NoticeKind uint16
+
+// NoticeKind_TypeID is the unique identifier for the type NoticeKind.
+const NoticeKind_TypeID = 0xb8974ae334e93d8e
+
+// Values of NoticeKind.
+const (
+	NoticeKind_info      NoticeKind = 0
+	NoticeKind_warning   NoticeKind = 1
+	NoticeKind_throttled NoticeKind = 2
+	NoticeKind_kicked    NoticeKind = 3
+)
+
+// String returns the enum's constant name.
+func (c NoticeKind) String() string {
+	switch c {
+	case NoticeKind_info:
+		return "info"
+	case NoticeKind_warning:
+		return "warning"
+	case NoticeKind_throttled:
+		return "throttled"
+	case NoticeKind_kicked:
+		return "kicked"
+
+	default:
+		return ""
+	}
+}
+
+// NoticeKindFromString returns the enum value with a name,
+// or the zero value if there's no such value.
+func NoticeKindFromString(c string) NoticeKind {
+	switch c {
+	case "info":
+		return NoticeKind_info
+	case "warning":
+		return NoticeKind_warning
+	case "throttled":
+		return NoticeKind_throttled
+	case "kicked":
+		return NoticeKind_kicked
+
+	default:
+		return 0
+	}
+}
+
+type NoticeKind_List = capnp.EnumList[NoticeKind]
+
+func NewNoticeKind_List(s *capnp.Segment, sz int32) (NoticeKind_List, error) {
+	return capnp.NewEnumList[NoticeKind](s, sz)
+}
+
+type GameServerNotice capnp.Struct
+
+// GameServerNotice_TypeID is the unique identifier for the type GameServerNotice.
+const GameServerNotice_TypeID = 0xb5dd1af0260a82d8
+
+func NewGameServerNotice(s *capnp.Segment) (GameServerNotice, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameServerNotice(st), err
+}
+
+func NewRootGameServerNotice(s *capnp.Segment) (GameServerNotice, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameServerNotice(st), err
+}
+
+func ReadRootGameServerNotice(msg *capnp.Message) (GameServerNotice, error) {
+	root, err := msg.Root()
+	return GameServerNotice(root.Struct()), err
+}
+
+func (s GameServerNotice) String() string {
+	str, _ := text.Marshal(0xb5dd1af0260a82d8, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerNotice) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerNotice) DecodeFromPtr(p capnp.Ptr) GameServerNotice {
+	return GameServerNotice(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerNotice) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerNotice) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerNotice) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerNotice) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerNotice) Kind() NoticeKind {
+	return NoticeKind(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameServerNotice) SetKind(v NoticeKind) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s GameServerNotice) Text() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameServerNotice) HasText() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerNotice) TextBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameServerNotice) SetText(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// GameServerNotice_List is a list of GameServerNotice.
+type GameServerNotice_List = capnp.StructList[GameServerNotice]
+
+// NewGameServerNotice creates a new list of GameServerNotice.
+func NewGameServerNotice_List(s *capnp.Segment, sz int32) (GameServerNotice_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerNotice](l), err
+}
+
+// GameServerNotice_Future is a wrapper for a GameServerNotice promised by a client call.
+type GameServerNotice_Future struct{ *capnp.Future }
+
+func (f GameServerNotice_Future) Struct() (GameServerNotice, error) {
+	p, err := f.Future.Ptr()
+	return GameServerNotice(p.Struct()), err
+}
+
+type ChatHistoryEntry capnp.Struct
+
+// ChatHistoryEntry_TypeID is the unique identifier for the type ChatHistoryEntry.
+const ChatHistoryEntry_TypeID = 0xf952c9641ce4d39b
+
+func NewChatHistoryEntry(s *capnp.Segment) (ChatHistoryEntry, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
+	return ChatHistoryEntry(st), err
+}
+
+func NewRootChatHistoryEntry(s *capnp.Segment) (ChatHistoryEntry, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4})
+	return ChatHistoryEntry(st), err
+}
+
+func ReadRootChatHistoryEntry(msg *capnp.Message) (ChatHistoryEntry, error) {
+	root, err := msg.Root()
+	return ChatHistoryEntry(root.Struct()), err
+}
+
+func (s ChatHistoryEntry) String() string {
+	str, _ := text.Marshal(0xf952c9641ce4d39b, capnp.Struct(s))
+	return str
+}
+
+func (s ChatHistoryEntry) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (ChatHistoryEntry) DecodeFromPtr(p capnp.Ptr) ChatHistoryEntry {
+	return ChatHistoryEntry(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s ChatHistoryEntry) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ChatHistoryEntry) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s ChatHistoryEntry) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s ChatHistoryEntry) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s ChatHistoryEntry) Seq() uint64 {
+	return capnp.Struct(s).Uint64(0)
+}
+
+func (s ChatHistoryEntry) SetSeq(v uint64) {
+	capnp.Struct(s).SetUint64(0, v)
+}
+
+func (s ChatHistoryEntry) Time() int64 {
+	return int64(capnp.Struct(s).Uint64(8))
+}
+
+func (s ChatHistoryEntry) SetTime(v int64) {
+	capnp.Struct(s).SetUint64(8, uint64(v))
+}
+
+func (s ChatHistoryEntry) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s ChatHistoryEntry) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s ChatHistoryEntry) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s ChatHistoryEntry) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s ChatHistoryEntry) Id() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s ChatHistoryEntry) HasId() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s ChatHistoryEntry) IdBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s ChatHistoryEntry) SetId(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s ChatHistoryEntry) Kind() ChatKind {
+	return ChatKind(capnp.Struct(s).Uint16(16))
+}
+
+func (s ChatHistoryEntry) SetKind(v ChatKind) {
+	capnp.Struct(s).SetUint16(16, uint16(v))
+}
+
+func (s ChatHistoryEntry) Text() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s ChatHistoryEntry) HasText() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s ChatHistoryEntry) TextBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s ChatHistoryEntry) SetText(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s ChatHistoryEntry) Target() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s ChatHistoryEntry) HasTarget() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s ChatHistoryEntry) TargetBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s ChatHistoryEntry) SetTarget(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+// ChatHistoryEntry_List is a list of ChatHistoryEntry.
+type ChatHistoryEntry_List = capnp.StructList[ChatHistoryEntry]
+
+// NewChatHistoryEntry creates a new list of ChatHistoryEntry.
+func NewChatHistoryEntry_List(s *capnp.Segment, sz int32) (ChatHistoryEntry_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 4}, sz)
+	return capnp.StructList[ChatHistoryEntry](l), err
+}
+
+// ChatHistoryEntry_Future is a wrapper for a ChatHistoryEntry promised by a client call.
+type ChatHistoryEntry_Future struct{ *capnp.Future }
+
+func (f ChatHistoryEntry_Future) Struct() (ChatHistoryEntry, error) {
+	p, err := f.Future.Ptr()
+	return ChatHistoryEntry(p.Struct()), err
+}
+
+type GameServerChatHistory capnp.Struct
+
+// GameServerChatHistory_TypeID is the unique identifier for the type GameServerChatHistory.
+const GameServerChatHistory_TypeID = 0xd7c8e652407e577c
+
+func NewGameServerChatHistory(s *capnp.Segment) (GameServerChatHistory, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameServerChatHistory(st), err
+}
+
+func NewRootGameServerChatHistory(s *capnp.Segment) (GameServerChatHistory, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return GameServerChatHistory(st), err
+}
+
+func ReadRootGameServerChatHistory(msg *capnp.Message) (GameServerChatHistory, error) {
+	root, err := msg.Root()
+	return GameServerChatHistory(root.Struct()), err
+}
+
+func (s GameServerChatHistory) String() string {
+	str, _ := text.Marshal(0xd7c8e652407e577c, capnp.Struct(s))
+	return str
+}
+
+func (s GameServerChatHistory) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameServerChatHistory) DecodeFromPtr(p capnp.Ptr) GameServerChatHistory {
+	return GameServerChatHistory(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameServerChatHistory) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameServerChatHistory) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameServerChatHistory) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameServerChatHistory) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameServerChatHistory) Kind() ChatKind {
+	return ChatKind(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameServerChatHistory) SetKind(v ChatKind) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s GameServerChatHistory) Entries() (ChatHistoryEntry_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ChatHistoryEntry_List(p.List()), err
+}
+
+func (s GameServerChatHistory) HasEntries() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameServerChatHistory) SetEntries(v ChatHistoryEntry_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewEntries sets the entries field to a newly
+// allocated ChatHistoryEntry_List, preferring placement in s's segment.
+func (s GameServerChatHistory) NewEntries(n int32) (ChatHistoryEntry_List, error) {
+	l, err := NewChatHistoryEntry_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return ChatHistoryEntry_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+func (s GameServerChatHistory) More() bool {
+	return capnp.Struct(s).Bit(16)
+}
+
+func (s GameServerChatHistory) SetMore(v bool) {
+	capnp.Struct(s).SetBit(16, v)
+}
+
+// GameServerChatHistory_List is a list of GameServerChatHistory.
+type GameServerChatHistory_List = capnp.StructList[GameServerChatHistory]
+
+// NewGameServerChatHistory creates a new list of GameServerChatHistory.
+func NewGameServerChatHistory_List(s *capnp.Segment, sz int32) (GameServerChatHistory_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[GameServerChatHistory](l), err
+}
+
+// GameServerChatHistory_Future is a wrapper for a GameServerChatHistory promised by a client call.
+type GameServerChatHistory_Future struct{ *capnp.Future }
+
+func (f GameServerChatHistory_Future) Struct() (GameServerChatHistory, error) {
+	p, err := f.Future.Ptr()
+	return GameServerChatHistory(p.Struct()), err
+}
+
+type GameClientChatHistory capnp.Struct
+
+// GameClientChatHistory_TypeID is the unique identifier for the type GameClientChatHistory.
+const GameClientChatHistory_TypeID = 0xf84cf363d6b2900c
+
+func NewGameClientChatHistory(s *capnp.Segment) (GameClientChatHistory, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameClientChatHistory(st), err
+}
+
+func NewRootGameClientChatHistory(s *capnp.Segment) (GameClientChatHistory, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return GameClientChatHistory(st), err
+}
+
+func ReadRootGameClientChatHistory(msg *capnp.Message) (GameClientChatHistory, error) {
+	root, err := msg.Root()
+	return GameClientChatHistory(root.Struct()), err
+}
+
+func (s GameClientChatHistory) String() string {
+	str, _ := text.Marshal(0xf84cf363d6b2900c, capnp.Struct(s))
+	return str
+}
+
+func (s GameClientChatHistory) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameClientChatHistory) DecodeFromPtr(p capnp.Ptr) GameClientChatHistory {
+	return GameClientChatHistory(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameClientChatHistory) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameClientChatHistory) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameClientChatHistory) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameClientChatHistory) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameClientChatHistory) Kind() ChatKind {
+	return ChatKind(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameClientChatHistory) SetKind(v ChatKind) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s GameClientChatHistory) Before() uint64 {
+	return capnp.Struct(s).Uint64(8)
+}
+
+func (s GameClientChatHistory) SetBefore(v uint64) {
+	capnp.Struct(s).SetUint64(8, v)
+}
+
+func (s GameClientChatHistory) Count() uint16 {
+	return capnp.Struct(s).Uint16(2)
+}
+
+func (s GameClientChatHistory) SetCount(v uint16) {
+	capnp.Struct(s).SetUint16(2, v)
+}
+
+// GameClientChatHistory_List is a list of GameClientChatHistory.
+type GameClientChatHistory_List = capnp.StructList[GameClientChatHistory]
+
+// NewGameClientChatHistory creates a new list of GameClientChatHistory.
+func NewGameClientChatHistory_List(s *capnp.Segment, sz int32) (GameClientChatHistory_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return capnp.StructList[GameClientChatHistory](l), err
+}
+
+// GameClientChatHistory_Future is a wrapper for a GameClientChatHistory promised by a client call.
+type GameClientChatHistory_Future struct{ *capnp.Future }
+
+func (f GameClientChatHistory_Future) Struct() (GameClientChatHistory, error) {
+	p, err := f.Future.Ptr()
+	return GameClientChatHistory(p.Struct()), err
+}
+
+type GameClientChat capnp.Struct
+
+// GameClientChat_TypeID is the unique identifier for the type GameClientChat.
+const GameClientChat_TypeID = 0x92ebca0fa2bbe017
+
+func NewGameClientChat(s *capnp.Segment) (GameClientChat, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameClientChat(st), err
+}
+
+func NewRootGameClientChat(s *capnp.Segment) (GameClientChat, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return GameClientChat(st), err
+}
+
+func ReadRootGameClientChat(msg *capnp.Message) (GameClientChat, error) {
+	root, err := msg.Root()
+	return GameClientChat(root.Struct()), err
+}
+
+func (s GameClientChat) String() string {
+	str, _ := text.Marshal(0x92ebca0fa2bbe017, capnp.Struct(s))
+	return str
+}
+
+func (s GameClientChat) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (GameClientChat) DecodeFromPtr(p capnp.Ptr) GameClientChat {
+	return GameClientChat(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s GameClientChat) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s GameClientChat) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s GameClientChat) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s GameClientChat) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s GameClientChat) Text() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s GameClientChat) HasText() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s GameClientChat) TextBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s GameClientChat) SetText(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s GameClientChat) Kind() ChatKind {
+	return ChatKind(capnp.Struct(s).Uint16(0))
+}
+
+func (s GameClientChat) SetKind(v ChatKind) {
+	capnp.Struct(s).SetUint16(0, uint16(v))
+}
+
+func (s GameClientChat) Target() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s GameClientChat) HasTarget() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s GameClientChat) TargetBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s GameClientChat) SetTarget(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+// GameClientChat_List is a list of GameClientChat.
+type GameClientChat_List = capnp.StructList[GameClientChat]
+
+// NewGameClientChat creates a new list of GameClientChat.
+func NewGameClientChat_List(s *capnp.Segment, sz int32) (GameClientChat_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return capnp.StructList[GameClientChat](l), err
+}
+
+// GameClientChat_Future is a wrapper for a GameClientChat promised by a client call.
+type GameClientChat_Future struct{ *capnp.Future }
+
+func (f GameClientChat_Future) Struct() (GameClientChat, error) {
 	p, err := f.Future.Ptr()
-	return GameBroadcastChat(p.Struct()), err
+	return GameClientChat(p.Struct()), err
 }
 
-type GameBroadcastPlayerMove capnp.Struct
+type GameClientSpectate capnp.Struct
 
-// GameBroadcastPlayerMove_TypeID is the unique identifier for the type GameBroadcastPlayerMove.
-const GameBroadcastPlayerMove_TypeID = 0x8e5205afc0f14fe0
+// GameClientSpectate_TypeID is the unique identifier for the type GameClientSpectate.
+const GameClientSpectate_TypeID = 0x84d5800eea49e0c9
 
-func NewGameBroadcastPlayerMove(s *capnp.Segment) (GameBroadcastPlayerMove, error) {
+func NewGameClientSpectate(s *capnp.Segment) (GameClientSpectate, error) {
 	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameBroadcastPlayerMove(st), err
+	return GameClientSpectate(st), err
 }
 
-func NewRootGameBroadcastPlayerMove(s *capnp.Segment) (GameBroadcastPlayerMove, error) {
+func NewRootGameClientSpectate(s *capnp.Segment) (GameClientSpectate, error) {
 	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameBroadcastPlayerMove(st), err
+	return GameClientSpectate(st), err
 }
 
-func ReadRootGameBroadcastPlayerMove(msg *capnp.Message) (GameBroadcastPlayerMove, error) {
+func ReadRootGameClientSpectate(msg *capnp.Message) (GameClientSpectate, error) {
 	root, err := msg.Root()
-	return GameBroadcastPlayerMove(root.Struct()), err
+	return GameClientSpectate(root.Struct()), err
 }
 
-func (s GameBroadcastPlayerMove) String() string {
-	str, _ := text.Marshal(0x8e5205afc0f14fe0, capnp.Struct(s))
+func (s GameClientSpectate) String() string {
+	str, _ := text.Marshal(0x84d5800eea49e0c9, capnp.Struct(s))
 	return str
 }
 
-func (s GameBroadcastPlayerMove) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameClientSpectate) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameBroadcastPlayerMove) DecodeFromPtr(p capnp.Ptr) GameBroadcastPlayerMove {
-	return GameBroadcastPlayerMove(capnp.Struct{}.DecodeFromPtr(p))
+func (GameClientSpectate) DecodeFromPtr(p capnp.Ptr) GameClientSpectate {
+	return GameClientSpectate(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameBroadcastPlayerMove) ToPtr() capnp.Ptr {
+func (s GameClientSpectate) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameBroadcastPlayerMove) IsValid() bool {
+func (s GameClientSpectate) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameBroadcastPlayerMove) Message() *capnp.Message {
+func (s GameClientSpectate) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameBroadcastPlayerMove) Segment() *capnp.Segment {
+func (s GameClientSpectate) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameBroadcastPlayerMove) Who() (Player, error) {
+func (s GameClientSpectate) Follow() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return Player(p.Struct()), err
+	return p.Text(), err
 }
 
-func (s GameBroadcastPlayerMove) HasWho() bool {
+func (s GameClientSpectate) HasFollow() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GameBroadcastPlayerMove) SetWho(v Player) error {
-	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+func (s GameClientSpectate) FollowBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
 }
 
-// NewWho sets the who field to a newly
-// allocated Player struct, preferring placement in s's segment.
-func (s GameBroadcastPlayerMove) NewWho() (Player, error) {
-	ss, err := NewPlayer(capnp.Struct(s).Segment())
-	if err != nil {
-		return Player{}, err
-	}
-	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
-	return ss, err
+func (s GameClientSpectate) SetFollow(v string) error {
+	return capnp.Struct(s).SetText(0, v)
 }
 
-// GameBroadcastPlayerMove_List is a list of GameBroadcastPlayerMove.
-type GameBroadcastPlayerMove_List = capnp.StructList[GameBroadcastPlayerMove]
+// GameClientSpectate_List is a list of GameClientSpectate.
+type GameClientSpectate_List = capnp.StructList[GameClientSpectate]
 
-// NewGameBroadcastPlayerMove creates a new list of GameBroadcastPlayerMove.
-func NewGameBroadcastPlayerMove_List(s *capnp.Segment, sz int32) (GameBroadcastPlayerMove_List, error) {
+// NewGameClientSpectate creates a new list of GameClientSpectate.
+func NewGameClientSpectate_List(s *capnp.Segment, sz int32) (GameClientSpectate_List, error) {
 	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
-	return capnp.StructList[GameBroadcastPlayerMove](l), err
+	return capnp.StructList[GameClientSpectate](l), err
 }
 
-// GameBroadcastPlayerMove_Future is a wrapper for a GameBroadcastPlayerMove promised by a client call.
-type GameBroadcastPlayerMove_Future struct{ *capnp.Future }
+// GameClientSpectate_Future is a wrapper for a GameClientSpectate promised by a client call.
+type GameClientSpectate_Future struct{ *capnp.Future }
 
-func (f GameBroadcastPlayerMove_Future) Struct() (GameBroadcastPlayerMove, error) {
+func (f GameClientSpectate_Future) Struct() (GameClientSpectate, error) {
 	p, err := f.Future.Ptr()
-	return GameBroadcastPlayerMove(p.Struct()), err
-}
-func (p GameBroadcastPlayerMove_Future) Who() Player_Future {
-	return Player_Future{Future: p.Future.Field(0, nil)}
+	return GameClientSpectate(p.Struct()), err
 }
 
-type GameServerGarbage capnp.Struct
+type GameClientRename capnp.Struct
 
-// GameServerGarbage_TypeID is the unique identifier for the type GameServerGarbage.
-const GameServerGarbage_TypeID = 0xd3f2426b260480f4
+// GameClientRename_TypeID is the unique identifier for the type GameClientRename.
+const GameClientRename_TypeID = 0x8d256dc9d9a6feeb
 
-func NewGameServerGarbage(s *capnp.Segment) (GameServerGarbage, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GameServerGarbage(st), err
+func NewGameClientRename(s *capnp.Segment) (GameClientRename, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameClientRename(st), err
 }
 
-func NewRootGameServerGarbage(s *capnp.Segment) (GameServerGarbage, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
-	return GameServerGarbage(st), err
+func NewRootGameClientRename(s *capnp.Segment) (GameClientRename, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return GameClientRename(st), err
 }
 
-func ReadRootGameServerGarbage(msg *capnp.Message) (GameServerGarbage, error) {
+func ReadRootGameClientRename(msg *capnp.Message) (GameClientRename, error) {
 	root, err := msg.Root()
-	return GameServerGarbage(root.Struct()), err
+	return GameClientRename(root.Struct()), err
 }
 
-func (s GameServerGarbage) String() string {
-	str, _ := text.Marshal(0xd3f2426b260480f4, capnp.Struct(s))
+func (s GameClientRename) String() string {
+	str, _ := text.Marshal(0x8d256dc9d9a6feeb, capnp.Struct(s))
 	return str
 }
 
-func (s GameServerGarbage) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameClientRename) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameServerGarbage) DecodeFromPtr(p capnp.Ptr) GameServerGarbage {
-	return GameServerGarbage(capnp.Struct{}.DecodeFromPtr(p))
+func (GameClientRename) DecodeFromPtr(p capnp.Ptr) GameClientRename {
+	return GameClientRename(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameServerGarbage) ToPtr() capnp.Ptr {
+func (s GameClientRename) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameServerGarbage) IsValid() bool {
+func (s GameClientRename) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameServerGarbage) Message() *capnp.Message {
+func (s GameClientRename) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameServerGarbage) Segment() *capnp.Segment {
+func (s GameClientRename) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameServerGarbage) Amount() uint32 {
-	return capnp.Struct(s).Uint32(0)
-}
-
-func (s GameServerGarbage) SetAmount(v uint32) {
-	capnp.Struct(s).SetUint32(0, v)
-}
-
-func (s GameServerGarbage) Per() uint8 {
-	return capnp.Struct(s).Uint8(4)
+func (s GameClientRename) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
 }
 
-func (s GameServerGarbage) SetPer(v uint8) {
-	capnp.Struct(s).SetUint8(4, v)
+func (s GameClientRename) HasName() bool {
+	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GameServerGarbage) Base() ([]byte, error) {
+func (s GameClientRename) NameBytes() ([]byte, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return []byte(p.Data()), err
-}
-
-func (s GameServerGarbage) HasBase() bool {
-	return capnp.Struct(s).HasPtr(0)
+	return p.TextBytes(), err
 }
 
-func (s GameServerGarbage) SetBase(v []byte) error {
-	return capnp.Struct(s).SetData(0, v)
+func (s GameClientRename) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
 }
 
-// GameServerGarbage_List is a list of GameServerGarbage.
-type GameServerGarbage_List = capnp.StructList[GameServerGarbage]
+// GameClientRename_List is a list of GameClientRename.
+type GameClientRename_List = capnp.StructList[GameClientRename]
 
-// NewGameServerGarbage creates a new list of GameServerGarbage.
-func NewGameServerGarbage_List(s *capnp.Segment, sz int32) (GameServerGarbage_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
-	return capnp.StructList[GameServerGarbage](l), err
+// NewGameClientRename creates a new list of GameClientRename.
+func NewGameClientRename_List(s *capnp.Segment, sz int32) (GameClientRename_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[GameClientRename](l), err
 }
 
-// GameServerGarbage_Future is a wrapper for a GameServerGarbage promised by a client call.
-type GameServerGarbage_Future struct{ *capnp.Future }
+// GameClientRename_Future is a wrapper for a GameClientRename promised by a client call.
+type GameClientRename_Future struct{ *capnp.Future }
 
-func (f GameServerGarbage_Future) Struct() (GameServerGarbage, error) {
+func (f GameClientRename_Future) Struct() (GameClientRename, error) {
 	p, err := f.Future.Ptr()
-	return GameServerGarbage(p.Struct()), err
+	return GameClientRename(p.Struct()), err
 }
 
-type GameServerGarbageAck capnp.Struct
+type GameClientPlayers capnp.Struct
 
-// GameServerGarbageAck_TypeID is the unique identifier for the type GameServerGarbageAck.
-const GameServerGarbageAck_TypeID = 0xcea719cc37fb77a0
+// GameClientPlayers_TypeID is the unique identifier for the type GameClientPlayers.
+const GameClientPlayers_TypeID = 0xb8f7b1be03718dbd
 
-func NewGameServerGarbageAck(s *capnp.Segment) (GameServerGarbageAck, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
-	return GameServerGarbageAck(st), err
+func NewGameClientPlayers(s *capnp.Segment) (GameClientPlayers, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return GameClientPlayers(st), err
 }
 
-func NewRootGameServerGarbageAck(s *capnp.Segment) (GameServerGarbageAck, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
-	return GameServerGarbageAck(st), err
+func NewRootGameClientPlayers(s *capnp.Segment) (GameClientPlayers, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return GameClientPlayers(st), err
 }
 
-func ReadRootGameServerGarbageAck(msg *capnp.Message) (GameServerGarbageAck, error) {
+func ReadRootGameClientPlayers(msg *capnp.Message) (GameClientPlayers, error) {
 	root, err := msg.Root()
-	return GameServerGarbageAck(root.Struct()), err
+	return GameClientPlayers(root.Struct()), err
 }
 
-func (s GameServerGarbageAck) String() string {
-	str, _ := text.Marshal(0xcea719cc37fb77a0, capnp.Struct(s))
+func (s GameClientPlayers) String() string {
+	str, _ := text.Marshal(0xb8f7b1be03718dbd, capnp.Struct(s))
 	return str
 }
 
-func (s GameServerGarbageAck) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameClientPlayers) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameServerGarbageAck) DecodeFromPtr(p capnp.Ptr) GameServerGarbageAck {
-	return GameServerGarbageAck(capnp.Struct{}.DecodeFromPtr(p))
+func (GameClientPlayers) DecodeFromPtr(p capnp.Ptr) GameClientPlayers {
+	return GameClientPlayers(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameServerGarbageAck) ToPtr() capnp.Ptr {
+func (s GameClientPlayers) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameServerGarbageAck) IsValid() bool {
+func (s GameClientPlayers) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameServerGarbageAck) Message() *capnp.Message {
+func (s GameClientPlayers) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameServerGarbageAck) Segment() *capnp.Segment {
+func (s GameClientPlayers) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameServerGarbageAck) Ack() uint32 {
-	return capnp.Struct(s).Uint32(0)
-}
-
-func (s GameServerGarbageAck) SetAck(v uint32) {
-	capnp.Struct(s).SetUint32(0, v)
-}
 
-// GameServerGarbageAck_List is a list of GameServerGarbageAck.
-type GameServerGarbageAck_List = capnp.StructList[GameServerGarbageAck]
+// GameClientPlayers_List is a list of GameClientPlayers.
+type GameClientPlayers_List = capnp.StructList[GameClientPlayers]
 
-// NewGameServerGarbageAck creates a new list of GameServerGarbageAck.
-func NewGameServerGarbageAck_List(s *capnp.Segment, sz int32) (GameServerGarbageAck_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
-	return capnp.StructList[GameServerGarbageAck](l), err
+// NewGameClientPlayers creates a new list of GameClientPlayers.
+func NewGameClientPlayers_List(s *capnp.Segment, sz int32) (GameClientPlayers_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[GameClientPlayers](l), err
 }
 
-// GameServerGarbageAck_Future is a wrapper for a GameServerGarbageAck promised by a client call.
-type GameServerGarbageAck_Future struct{ *capnp.Future }
+// GameClientPlayers_Future is a wrapper for a GameClientPlayers promised by a client call.
+type GameClientPlayers_Future struct{ *capnp.Future }
 
-func (f GameServerGarbageAck_Future) Struct() (GameServerGarbageAck, error) {
+func (f GameClientPlayers_Future) Struct() (GameClientPlayers, error) {
 	p, err := f.Future.Ptr()
-	return GameServerGarbageAck(p.Struct()), err
+	return GameClientPlayers(p.Struct()), err
 }
 
-type GameServerPlayers capnp.Struct
+type GameClientChangeWorld capnp.Struct
 
-// GameServerPlayers_TypeID is the unique identifier for the type GameServerPlayers.
-const GameServerPlayers_TypeID = 0xaaccfc7400a32fc1
+// GameClientChangeWorld_TypeID is the unique identifier for the type GameClientChangeWorld.
+const GameClientChangeWorld_TypeID = 0xa5cb2b9d2fe2005a
 
-func NewGameServerPlayers(s *capnp.Segment) (GameServerPlayers, error) {
+func NewGameClientChangeWorld(s *capnp.Segment) (GameClientChangeWorld, error) {
 	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameServerPlayers(st), err
+	return GameClientChangeWorld(st), err
 }
 
-func NewRootGameServerPlayers(s *capnp.Segment) (GameServerPlayers, error) {
+func NewRootGameClientChangeWorld(s *capnp.Segment) (GameClientChangeWorld, error) {
 	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameServerPlayers(st), err
+	return GameClientChangeWorld(st), err
 }
 
-func ReadRootGameServerPlayers(msg *capnp.Message) (GameServerPlayers, error) {
+func ReadRootGameClientChangeWorld(msg *capnp.Message) (GameClientChangeWorld, error) {
 	root, err := msg.Root()
-	return GameServerPlayers(root.Struct()), err
+	return GameClientChangeWorld(root.Struct()), err
 }
 
-func (s GameServerPlayers) String() string {
-	str, _ := text.Marshal(0xaaccfc7400a32fc1, capnp.Struct(s))
+func (s GameClientChangeWorld) String() string {
+	str, _ := text.Marshal(0xa5cb2b9d2fe2005a, capnp.Struct(s))
 	return str
 }
 
-func (s GameServerPlayers) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameClientChangeWorld) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameServerPlayers) DecodeFromPtr(p capnp.Ptr) GameServerPlayers {
-	return GameServerPlayers(capnp.Struct{}.DecodeFromPtr(p))
+func (GameClientChangeWorld) DecodeFromPtr(p capnp.Ptr) GameClientChangeWorld {
+	return GameClientChangeWorld(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameServerPlayers) ToPtr() capnp.Ptr {
+func (s GameClientChangeWorld) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameServerPlayers) IsValid() bool {
+func (s GameClientChangeWorld) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameServerPlayers) Message() *capnp.Message {
+func (s GameClientChangeWorld) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameServerPlayers) Segment() *capnp.Segment {
+func (s GameClientChangeWorld) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameServerPlayers) Players() (Player_List, error) {
+func (s GameClientChangeWorld) Name() (string, error) {
 	p, err := capnp.Struct(s).Ptr(0)
-	return Player_List(p.List()), err
+	return p.Text(), err
 }
 
-func (s GameServerPlayers) HasPlayers() bool {
+func (s GameClientChangeWorld) HasName() bool {
 	return capnp.Struct(s).HasPtr(0)
 }
 
-func (s GameServerPlayers) SetPlayers(v Player_List) error {
-	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+func (s GameClientChangeWorld) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
 }
 
-// NewPlayers sets the players field to a newly
-// allocated Player_List, preferring placement in s's segment.
-func (s GameServerPlayers) NewPlayers(n int32) (Player_List, error) {
-	l, err := NewPlayer_List(capnp.Struct(s).Segment(), n)
-	if err != nil {
-		return Player_List{}, err
-	}
-	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
-	return l, err
+func (s GameClientChangeWorld) SetName(v string) error {
+	return capnp.Struct(s).SetText(0, v)
 }
 
-// GameServerPlayers_List is a list of GameServerPlayers.
-type GameServerPlayers_List = capnp.StructList[GameServerPlayers]
+// GameClientChangeWorld_List is a list of GameClientChangeWorld.
+type GameClientChangeWorld_List = capnp.StructList[GameClientChangeWorld]
 
-// NewGameServerPlayers creates a new list of GameServerPlayers.
-func NewGameServerPlayers_List(s *capnp.Segment, sz int32) (GameServerPlayers_List, error) {
+// NewGameClientChangeWorld creates a new list of GameClientChangeWorld.
+func NewGameClientChangeWorld_List(s *capnp.Segment, sz int32) (GameClientChangeWorld_List, error) {
 	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
-	return capnp.StructList[GameServerPlayers](l), err
+	return capnp.StructList[GameClientChangeWorld](l), err
 }
 
-// GameServerPlayers_Future is a wrapper for a GameServerPlayers promised by a client call.
-type GameServerPlayers_Future struct{ *capnp.Future }
+// GameClientChangeWorld_Future is a wrapper for a GameClientChangeWorld promised by a client call.
+type GameClientChangeWorld_Future struct{ *capnp.Future }
 
-func (f GameServerPlayers_Future) Struct() (GameServerPlayers, error) {
+func (f GameClientChangeWorld_Future) Struct() (GameClientChangeWorld, error) {
 	p, err := f.Future.Ptr()
-	return GameServerPlayers(p.Struct()), err
+	return GameClientChangeWorld(p.Struct()), err
 }
 
-type GameClientChat capnp.Struct
+type GameClientMoveTo capnp.Struct
 
-// GameClientChat_TypeID is the unique identifier for the type GameClientChat.
-const GameClientChat_TypeID = 0x92ebca0fa2bbe017
+// GameClientMoveTo_TypeID is the unique identifier for the type GameClientMoveTo.
+const GameClientMoveTo_TypeID = 0xd36fc4846e94fc1d
 
-func NewGameClientChat(s *capnp.Segment) (GameClientChat, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameClientChat(st), err
+func NewGameClientMoveTo(s *capnp.Segment) (GameClientMoveTo, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return GameClientMoveTo(st), err
 }
 
-func NewRootGameClientChat(s *capnp.Segment) (GameClientChat, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
-	return GameClientChat(st), err
+func NewRootGameClientMoveTo(s *capnp.Segment) (GameClientMoveTo, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return GameClientMoveTo(st), err
 }
 
-func ReadRootGameClientChat(msg *capnp.Message) (GameClientChat, error) {
+func ReadRootGameClientMoveTo(msg *capnp.Message) (GameClientMoveTo, error) {
 	root, err := msg.Root()
-	return GameClientChat(root.Struct()), err
+	return GameClientMoveTo(root.Struct()), err
 }
 
-func (s GameClientChat) String() string {
-	str, _ := text.Marshal(0x92ebca0fa2bbe017, capnp.Struct(s))
+func (s GameClientMoveTo) String() string {
+	str, _ := text.Marshal(0xd36fc4846e94fc1d, capnp.Struct(s))
 	return str
 }
 
-func (s GameClientChat) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+func (s GameClientMoveTo) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
 	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-func (GameClientChat) DecodeFromPtr(p capnp.Ptr) GameClientChat {
-	return GameClientChat(capnp.Struct{}.DecodeFromPtr(p))
+func (GameClientMoveTo) DecodeFromPtr(p capnp.Ptr) GameClientMoveTo {
+	return GameClientMoveTo(capnp.Struct{}.DecodeFromPtr(p))
 }
 
-func (s GameClientChat) ToPtr() capnp.Ptr {
+func (s GameClientMoveTo) ToPtr() capnp.Ptr {
 	return capnp.Struct(s).ToPtr()
 }
-func (s GameClientChat) IsValid() bool {
+func (s GameClientMoveTo) IsValid() bool {
 	return capnp.Struct(s).IsValid()
 }
 
-func (s GameClientChat) Message() *capnp.Message {
+func (s GameClientMoveTo) Message() *capnp.Message {
 	return capnp.Struct(s).Message()
 }
 
-func (s GameClientChat) Segment() *capnp.Segment {
+func (s GameClientMoveTo) Segment() *capnp.Segment {
 	return capnp.Struct(s).Segment()
 }
-func (s GameClientChat) Text() (string, error) {
-	p, err := capnp.Struct(s).Ptr(0)
-	return p.Text(), err
+func (s GameClientMoveTo) X() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
 }
 
-func (s GameClientChat) HasText() bool {
-	return capnp.Struct(s).HasPtr(0)
+func (s GameClientMoveTo) SetX(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
 }
 
-func (s GameClientChat) TextBytes() ([]byte, error) {
-	p, err := capnp.Struct(s).Ptr(0)
-	return p.TextBytes(), err
+func (s GameClientMoveTo) Y() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
 }
 
-func (s GameClientChat) SetText(v string) error {
-	return capnp.Struct(s).SetText(0, v)
+func (s GameClientMoveTo) SetY(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
 }
 
-// GameClientChat_List is a list of GameClientChat.
-type GameClientChat_List = capnp.StructList[GameClientChat]
+// GameClientMoveTo_List is a list of GameClientMoveTo.
+type GameClientMoveTo_List = capnp.StructList[GameClientMoveTo]
 
-// NewGameClientChat creates a new list of GameClientChat.
-func NewGameClientChat_List(s *capnp.Segment, sz int32) (GameClientChat_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
-	return capnp.StructList[GameClientChat](l), err
+// NewGameClientMoveTo creates a new list of GameClientMoveTo.
+func NewGameClientMoveTo_List(s *capnp.Segment, sz int32) (GameClientMoveTo_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[GameClientMoveTo](l), err
 }
 
-// GameClientChat_Future is a wrapper for a GameClientChat promised by a client call.
-type GameClientChat_Future struct{ *capnp.Future }
+// GameClientMoveTo_Future is a wrapper for a GameClientMoveTo promised by a client call.
+type GameClientMoveTo_Future struct{ *capnp.Future }
 
-func (f GameClientChat_Future) Struct() (GameClientChat, error) {
+func (f GameClientMoveTo_Future) Struct() (GameClientMoveTo, error) {
 	p, err := f.Future.Ptr()
-	return GameClientChat(p.Struct()), err
+	return GameClientMoveTo(p.Struct()), err
 }
 
 type GameClientMoved capnp.Struct
@@ -811,6 +3344,14 @@ func (s GameClientMoved) SetY(v int8) {
 	capnp.Struct(s).SetUint8(1, uint8(v))
 }
 
+func (s GameClientMoved) Seq() uint32 {
+	return capnp.Struct(s).Uint32(4)
+}
+
+func (s GameClientMoved) SetSeq(v uint32) {
+	capnp.Struct(s).SetUint32(4, v)
+}
+
 // GameClientMoved_List is a list of GameClientMoved.
 type GameClientMoved_List = capnp.StructList[GameClientMoved]
 
@@ -911,12 +3452,12 @@ type GameClientGarbage capnp.Struct
 const GameClientGarbage_TypeID = 0x991d0e65a6c49290
 
 func NewGameClientGarbage(s *capnp.Segment) (GameClientGarbage, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
 	return GameClientGarbage(st), err
 }
 
 func NewRootGameClientGarbage(s *capnp.Segment) (GameClientGarbage, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
 	return GameClientGarbage(st), err
 }
 
@@ -975,13 +3516,28 @@ func (s GameClientGarbage) NewHash(n int32) (GarbageData_List, error) {
 	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
 	return l, err
 }
+func (s GameClientGarbage) Batch() uint32 {
+	return capnp.Struct(s).Uint32(0)
+}
+
+func (s GameClientGarbage) SetBatch(v uint32) {
+	capnp.Struct(s).SetUint32(0, v)
+}
+
+func (s GameClientGarbage) Challenge() uint32 {
+	return capnp.Struct(s).Uint32(4)
+}
+
+func (s GameClientGarbage) SetChallenge(v uint32) {
+	capnp.Struct(s).SetUint32(4, v)
+}
 
 // GameClientGarbage_List is a list of GameClientGarbage.
 type GameClientGarbage_List = capnp.StructList[GameClientGarbage]
 
 // NewGameClientGarbage creates a new list of GameClientGarbage.
 func NewGameClientGarbage_List(s *capnp.Segment, sz int32) (GameClientGarbage_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
 	return capnp.StructList[GameClientGarbage](l), err
 }
 
@@ -3,17 +3,43 @@ package backend
 // Simple in memory DB for testing
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
 	"log"
+	"maps"
 	"sync"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrLoginSecret = errors.New("wrong secret for that name")
 )
 
 type TransportSchema struct {
 	user    uuid.UUID
 	expires time.Time
+	login   LoginOptions
+}
+
+// LoginOptions
+// Picked at login, carried over to the wt connect by the transport code.
+type LoginOptions struct {
+	// World to join, empty for default.
+	World string
+	// Spectate watch without playing.
+	Spectate bool
+	// Follow player name or ID a spectator watches, empty for everything.
+	Follow string
+	// GarbageHashes and GarbageEncodings the client supports, nil for only the defaults.
+	GarbageHashes    []cpnp.GarbageHash
+	GarbageEncodings []cpnp.GarbageEncoding
+	GarbageModes     []cpnp.GarbageMode
 }
 
 type TransportDatabase struct {
@@ -30,11 +56,17 @@ func NewTransportDatabase() *TransportDatabase {
 type UserDatabase struct {
 	mu    sync.Mutex
 	users map[uuid.UUID]string
+	// names index of nameKey to user, keeps names unique.
+	names map[string]uuid.UUID
+	// secrets hashed login secret of each user made by LoginUser.
+	secrets map[uuid.UUID][sha256.Size]byte
 }
 
 func NewUserDatabase() *UserDatabase {
 	return &UserDatabase{
-		users: make(map[uuid.UUID]string),
+		users:   make(map[uuid.UUID]string),
+		names:   make(map[string]uuid.UUID),
+		secrets: make(map[uuid.UUID][sha256.Size]byte),
 	}
 }
 
@@ -50,25 +82,144 @@ func NewDatabaseManager() *DatabaseManager {
 	}
 }
 
+// GetUser
+// Finds a user by name ignoring case, makes one if no one has it.
+// Fails if the name isn't allowed, see CleanName.
 func (db *DatabaseManager) GetUser(name string) (uuid.UUID, error) {
+	name, err := CleanName(name)
+	if err != nil {
+		return uuid.Nil, err
+	}
 	db.Users.mu.Lock()
 	defer db.Users.mu.Unlock()
-	uid := uuid.Nil
-	for id, u := range db.Users.users {
-		if u == name {
-			uid = id
-			return uid, nil
+	if uid, ok := db.Users.names[nameKey(name)]; ok {
+		return uid, nil
+	}
+	return db.Users.add(name)
+}
+
+// LoginUser
+// GetUser for logins, a new name gets a secret that has to come back to log in as it again.
+// The secret is only returned for new users, existing ones need theirs or get ErrLoginSecret.
+func (db *DatabaseManager) LoginUser(name, secret string) (uuid.UUID, string, error) {
+	name, err := CleanName(name)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	db.Users.mu.Lock()
+	defer db.Users.mu.Unlock()
+	if uid, ok := db.Users.names[nameKey(name)]; ok {
+		// Users from before secrets have none and can't be logged in to.
+		want, ok := db.Users.secrets[uid]
+		got := sha256.Sum256([]byte(secret))
+		if !ok || secret == "" || subtle.ConstantTimeCompare(want[:], got[:]) != 1 {
+			return uuid.Nil, "", ErrLoginSecret
 		}
+		return uid, "", nil
 	}
 
+	return db.Users.addWithSecret(name)
+}
+
+// ReserveUser
+// Makes sure a name has a user so no one else can take it, returns the secret if it made one.
+func (db *DatabaseManager) ReserveUser(name string) (uuid.UUID, string, error) {
+	name, err := CleanName(name)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	db.Users.mu.Lock()
+	defer db.Users.mu.Unlock()
+	if uid, ok := db.Users.names[nameKey(name)]; ok {
+		return uid, "", nil
+	}
+	return db.Users.addWithSecret(name)
+}
+
+// addWithSecret
+// add with a new login secret, expects u.mu to be held.
+func (u *UserDatabase) addWithSecret(name string) (uuid.UUID, string, error) {
+	uid, err := u.add(name)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	secret := rand.Text()
+	u.secrets[uid] = sha256.Sum256([]byte(secret))
+	return uid, secret, nil
+}
+
+// add
+// New user with a clean name no one has, expects u.mu to be held.
+func (u *UserDatabase) add(name string) (uuid.UUID, error) {
 	uid, err := uuid.NewV7()
 	if err != nil {
 		log.Println("UID v7", err)
 		return uuid.Nil, err
 	}
+	u.users[uid] = name
+	u.names[nameKey(name)] = uid
+	return uid, nil
+}
+
+// RenameUser
+// Changes a user's name, returns the old one and the cleaned up new one.
+// Only changing the case of your own name is fine.
+func (db *DatabaseManager) RenameUser(uid uuid.UUID, name string) (string, string, error) {
+	name, err := CleanName(name)
+	if err != nil {
+		return "", "", err
+	}
+	db.Users.mu.Lock()
+	defer db.Users.mu.Unlock()
+	old, ok := db.Users.users[uid]
+	if !ok {
+		return "", "", errors.New("user not found")
+	}
+	if other, ok := db.Users.names[nameKey(name)]; ok && other != uid {
+		return "", "", ErrNameTaken
+	}
+	delete(db.Users.names, nameKey(old))
 	db.Users.users[uid] = name
+	db.Users.names[nameKey(name)] = uid
+	return old, name, nil
+}
 
-	return uid, nil
+// Snapshot
+// Copy of every user.
+func (u *UserDatabase) Snapshot() map[uuid.UUID]string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return maps.Clone(u.users)
+}
+
+// Secrets
+// Copy of every user's hashed login secret.
+func (u *UserDatabase) Secrets() map[uuid.UUID][sha256.Size]byte {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return maps.Clone(u.secrets)
+}
+
+// RestoreSecrets
+// Adds saved login secrets back, used at startup.
+func (u *UserDatabase) RestoreSecrets(secrets map[uuid.UUID][sha256.Size]byte) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	maps.Copy(u.secrets, secrets)
+}
+
+// Restore
+// Adds saved users back, used at startup.
+func (u *UserDatabase) Restore(users map[uuid.UUID]string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	maps.Copy(u.users, users)
+	for id, name := range users {
+		if other, ok := u.names[nameKey(name)]; ok && other != id {
+			log.Printf("Error restoring user %s: name %q taken by %s\n", id, name, other)
+		}
+		u.names[nameKey(name)] = id
+	}
 }
 
 func (db *DatabaseManager) GetUserByID(uid uuid.UUID) (string, error) {
@@ -82,6 +233,14 @@ func (db *DatabaseManager) GetUserByID(uid uuid.UUID) (string, error) {
 }
 
 func (db *DatabaseManager) NewTransport(uid uuid.UUID) (uuid.UUID, error) {
+	return db.NewTransportWorld(uid, "")
+}
+
+func (db *DatabaseManager) NewTransportWorld(uid uuid.UUID, world string) (uuid.UUID, error) {
+	return db.NewTransportLogin(uid, LoginOptions{World: world})
+}
+
+func (db *DatabaseManager) NewTransportLogin(uid uuid.UUID, login LoginOptions) (uuid.UUID, error) {
 	db.pruneTransport()
 
 	db.Transport.mu.Lock()
@@ -95,6 +254,7 @@ func (db *DatabaseManager) NewTransport(uid uuid.UUID) (uuid.UUID, error) {
 	db.Transport.codes[code] = TransportSchema{
 		user:    uid,
 		expires: time.Now().Add(time.Minute * 5),
+		login:   login,
 	}
 
 	return code, nil
@@ -111,26 +271,53 @@ func (db *DatabaseManager) pruneTransport() {
 }
 
 func (db *DatabaseManager) VerifyTransport(code uuid.UUID) (uuid.UUID, error) {
+	uid, _, err := db.VerifyTransportWorld(code)
+	return uid, err
+}
+
+// VerifyTransportWorld
+// VerifyTransport but also returns the world picked at login.
+func (db *DatabaseManager) VerifyTransportWorld(code uuid.UUID) (uuid.UUID, string, error) {
+	uid, login, err := db.VerifyTransportLogin(code)
+	return uid, login.World, err
+}
+
+// VerifyTransportLogin
+// VerifyTransport but also returns everything picked at login.
+func (db *DatabaseManager) VerifyTransportLogin(code uuid.UUID) (uuid.UUID, LoginOptions, error) {
 	db.pruneTransport()
 	db.Transport.mu.Lock()
 	defer db.Transport.mu.Unlock()
 	s, ok := db.Transport.codes[code]
 	if !ok {
-		return uuid.Nil, errors.New("transport not found")
+		return uuid.Nil, LoginOptions{}, errors.New("transport not found")
 	}
 
 	uid := s.user
 	delete(db.Transport.codes, code)
 
-	return uid, nil
+	return uid, s.login, nil
 }
 
 func (db *DatabaseManager) Login(name string) (uuid.UUID, error) {
+	return db.LoginWorld(name, "")
+}
+
+// LoginWorld
+// Login and remember which world to join.
+func (db *DatabaseManager) LoginWorld(name, world string) (uuid.UUID, error) {
+	return db.LoginWith(name, LoginOptions{World: world})
+}
+
+// LoginWith
+// Login with any of the options.
+// Doesn't check secrets, for tests and tools, the server uses LoginUser.
+func (db *DatabaseManager) LoginWith(name string, login LoginOptions) (uuid.UUID, error) {
 	uid, err := db.GetUser(name)
 	if err != nil {
 		return uuid.Nil, err
 	}
-	code, err := db.NewTransport(uid)
+	code, err := db.NewTransportLogin(uid, login)
 	if err != nil {
 		return uuid.Nil, err
 	}
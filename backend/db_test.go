@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/go-faker/faker/v4"
@@ -50,3 +51,33 @@ func testDB(tb testing.TB, db *DatabaseManager) {
 		tb.FailNow()
 	}
 }
+
+func TestLoginUser(t *testing.T) {
+	db := NewDatabaseManager()
+
+	uid, secret, err := db.LoginUser("Alice", "")
+	if err != nil || secret == "" {
+		t.Fatalf("new user: secret %q, %v", secret, err)
+	}
+	for _, tt := range []struct{ name, secret string }{
+		{"Alice", ""},
+		{"ALICE", ""},
+		{"alice", "wrong"},
+	} {
+		if _, _, err := db.LoginUser(tt.name, tt.secret); !errors.Is(err, ErrLoginSecret) {
+			t.Errorf("LoginUser(%q, %q) = %v, want wrong secret", tt.name, tt.secret, err)
+		}
+	}
+	again, fresh, err := db.LoginUser("ALICE", secret)
+	if err != nil || again != uid || fresh != "" {
+		t.Errorf("with secret: %s, %q, %v want %s", again, fresh, err, uid)
+	}
+
+	// Made without a secret, no one can log in as it.
+	if _, err := db.GetUser("No Secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := db.LoginUser("no secret", ""); !errors.Is(err, ErrLoginSecret) {
+		t.Errorf("user without secret: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// EventKind
+// What happened in an Event.
+type EventKind string
+
+const (
+	// EventWorld a world was created, has the seed and map settings.
+	EventWorld      EventKind = "world"
+	EventConnect    EventKind = "connect"
+	EventDisconnect EventKind = "disconnect"
+	EventMove       EventKind = "move"
+	EventChat       EventKind = "chat"
+	// EventGarbage a garbage packet was checked, Ok if it passed.
+	EventGarbage    EventKind = "garbage"
+	EventItemSpawn  EventKind = "item_spawn"
+	EventItemPickup EventKind = "item_pickup"
+	// EventRename Name is the new name, Text the old one.
+	EventRename EventKind = "rename"
+)
+
+// Event
+// One state change in a world, a line in the event log.
+type Event struct {
+	Tick  uint64    `json:"tick"`
+	Time  time.Time `json:"time"`
+	World string    `json:"world"`
+	Kind  EventKind `json:"kind"`
+
+	// Player or NPC
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+
+	Text    string `json:"text,omitempty"`
+	Channel string `json:"channel,omitempty"`
+	Target  string `json:"target,omitempty"`
+	Ok      bool   `json:"ok,omitempty"`
+	Item    uint32 `json:"item,omitempty"`
+
+	// EventWorld only
+	Seed      uint64 `json:"seed,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Walls     int    `json:"walls,omitempty"`
+	Collision bool   `json:"collision,omitempty"`
+}
+
+// EventLog
+// Append only JSON lines file of Events.
+// A nil EventLog drops everything so worlds don't have to check.
+type EventLog struct {
+	mu  sync.Mutex
+	f   *os.File
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+// OpenEventLog
+// Opens or creates a log, new events go on the end.
+func OpenEventLog(path string) (*EventLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	buf := bufio.NewWriter(f)
+	return &EventLog{f: f, buf: buf, enc: json.NewEncoder(buf)}, nil
+}
+
+func (l *EventLog) Append(e Event) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	err := l.enc.Encode(e)
+	if err != nil {
+		log.Printf("Error writing event: %v\n", err)
+	}
+}
+
+// Flush
+// Writes buffered events out, worlds do this every tick.
+func (l *EventLog) Flush() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return
+	}
+	err := l.buf.Flush()
+	if err != nil {
+		log.Printf("Error flushing event log: %v\n", err)
+	}
+}
+
+func (l *EventLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	f := l.f
+	l.f = nil
+	err := l.buf.Flush()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// record
+// Fills in the world, tick and time and appends.
+func (w *GameWorld) record(e Event) {
+	if w.events == nil {
+		return
+	}
+	e.Tick = w.Tick.Load()
+	e.Time = time.Now()
+	e.World = w.Name
+	w.events.Append(e)
+}
@@ -1,11 +1,17 @@
 package backend
 
 import (
+	"fmt"
 	"log"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"capnproto.org/go/capnp/v3"
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
 )
 
 type Player struct {
@@ -14,102 +20,276 @@ type Player struct {
 
 	mu sync.Mutex
 
+	// LastInput sequence number of the last move processed.
+	LastInput uint32
+
+	// Presence last sent out, see tickPresence.
+	Presence cpnp.Presence
+
+	// Inventory items picked up, follows the player between worlds.
+	Inventory Inventory
+
+	// MoveTo cells still to walk.
+	path []Cell
+
+	// resync limits asking for the player list again.
+	resync tokenBucket
+
+	// Movement limiting
+	moveBudget     tokenBucket
+	moveQueue      []queuedInput
+	moveViolations violations
+	moveThrottled  bool
+	MoveStats      MovementStats
+
+	garbageViolations violations
+	// garbageCooldown when the next challenge goes out, zero if not waiting.
+	garbageCooldown time.Time
+
 	GarbageFailed int
 	GarbageAmount int      // Amount per message
 	GarbageTotal  int      // Amount Total needed
 	GarbageBase   [20]byte // SHA1 of time
+
+	GarbageHash     cpnp.GarbageHash
+	GarbageEncoding cpnp.GarbageEncoding
+	GarbageMode     cpnp.GarbageMode
+	// GarbageDifficulty leading zero bits in work mode.
+	GarbageDifficulty int
+	garbageNonce      uint64
+	// garbageChallenge counts challenges sent, garbageBatches batches taken in this one.
+	garbageChallenge uint32
+	garbageBatches   uint32
+	garbageStarted   time.Time
 }
 
 type GameWorld struct {
+	Name string
+
 	Players map[*Session]*Player
 	pmu     sync.RWMutex
 
-	db *DatabaseManager
+	Map    *GameMap
+	config GameConfig
+
+	// Spectators watching, not in Players.
+	Spectators map[*Session]*Spectator
+	spmu       sync.RWMutex
+
+	// NPCs entities with no session, moved on the tick.
+	NPCs map[uuid.UUID]*NPC
+	nmu  sync.RWMutex
+
+	// Items on the map and the ones waiting to respawn.
+	items    map[Cell]*Item
+	respawns []itemRespawn
+	nextItem uint32
+	imu      sync.Mutex
+
+	// World and emote chat history.
+	history *ChatHistory
+
+	db      *DatabaseManager
+	manager *WorldManager
 
 	writer *PacketWriter
 	reader *PacketReader
 
+	// Tick number of world updates so far.
+	Tick atomic.Uint64
+	// tickTime how long the last tick took.
+	tickTime atomic.Int64
+
+	// Seed the map was made with, see GameMap.Seed.
+	Seed   uint64
+	events *EventLog
+
 	Closed chan bool
 }
 
-func NewGameWorld(db *DatabaseManager) *GameWorld {
+func NewGameWorld(name string, db *DatabaseManager, config GameConfig) *GameWorld {
+	return newGameWorld(name, db, config, nil)
+}
+
+// newGameWorld
+// With an event log so the setup gets recorded too.
+func newGameWorld(name string, db *DatabaseManager, config GameConfig, events *EventLog) *GameWorld {
 	gw := &GameWorld{
-		db: db,
+		Name:   name,
+		db:     db,
+		config: config,
+		events: events,
+		Seed:   config.Seed,
 
 		Players: make(map[*Session]*Player),
-		// Closed:  make(chan bool, 1),
+		NPCs:    make(map[uuid.UUID]*NPC),
+		items:   make(map[Cell]*Item),
+		Map:     NewGameMap(config.MapWidth, config.MapHeight, config.Collision),
+		Closed:  make(chan bool, 1),
+
+		Spectators: make(map[*Session]*Spectator),
+
+		history: NewChatHistory(config.Chat.History),
 
 		writer: NewPacketWriter(),
 		reader: NewPacketReader(),
 	}
+	if gw.Seed == 0 {
+		gw.Seed = rand.Uint64()
+	}
+	gw.Map.Seed(gw.Seed)
+	gw.record(Event{
+		Kind:      EventWorld,
+		Seed:      gw.Seed,
+		Width:     gw.Map.Width,
+		Height:    gw.Map.Height,
+		Walls:     config.Walls,
+		Collision: config.Collision,
+	})
+	gw.Map.RandomWalls(config.Walls)
+	gw.spawnItems()
+	for i := range config.NPC.Count {
+		_, err := gw.AddNPC(fmt.Sprintf("NPC %d", i+1), RandomBehaviour(gw.Map))
+		if err != nil {
+			log.Printf("Error adding NPC to %s: %v\n", name, err)
+			break
+		}
+	}
 	return gw
 }
 
+// Start
+// Runs the world tick until Shutdown.
 func (w *GameWorld) Start() {
-	w.Closed = make(chan bool, 1)
+	rate := w.config.TickRate
+	if rate <= 0 {
+		rate = 50 * time.Millisecond
+	}
+	ticker := time.NewTicker(rate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.Closed:
+			return
+		case <-ticker.C:
+			w.tick()
+		}
+	}
+}
+
+func (w *GameWorld) tick() {
+	w.Tick.Add(1)
+	now := time.Now()
+	w.tickMovement(now)
+	w.tickPaths(now)
+	w.tickPresence(now)
+	w.tickNPCs(now)
+	w.tickItems(now)
+	w.tickGarbage(now)
+	w.events.Flush()
+	w.tickTime.Store(int64(time.Since(now)))
 }
 
 func (w *GameWorld) Shutdown() {
 	close(w.Closed)
 }
 
-func (w *GameWorld) Connect(session *Session) {
+func (w *GameWorld) Connect(session *Session) error {
+	if session.Spectator {
+		w.connectSpectator(session)
+		return nil
+	}
 	w.pmu.RLock()
 	_, ok := w.Players[session]
 	if ok {
 		w.pmu.RUnlock()
 		log.Println("Player already connected.")
-		return
+		return nil
 	}
 	w.pmu.RUnlock()
 
 	name, err := w.db.GetUserByID(session.ID)
 	if err != nil {
 		log.Printf("Error getting user by id: %v\n", err)
-		return
+		return err
 	}
 
-	// Adds all opcodes for the game
-	w.connectOpcodes(session)
-
-	log.Printf("Connecting: %s, ID: %s\n", name, session.ID)
+	log.Printf("Connecting: %s, ID: %s, World: %s\n", name, session.ID, w.Name)
 
-	w.pmu.Lock()
+	session.Touch()
 	pl := new(Player)
 	pl.Name = name
-	pl.X = rand.IntN(100)
-	pl.Y = rand.IntN(100)
+	pl.moveBudget = newTokenBucket(w.config.Movement.Limit)
+	pl.resync = newTokenBucket(w.config.Resync)
+	err = w.spawnPlayer(session, pl)
+	if err != nil {
+		log.Printf("Error spawning %s: %v\n", name, err)
+		return err
+	}
+
+	// Adds all opcodes for the game, only once there's a player for them.
+	w.connectOpcodes(session)
+
+	w.pmu.Lock()
 	w.Players[session] = pl
 	w.pmu.Unlock()
-	w.playerConnectedSend(session, name, true, true)
+	pl.mu.Lock()
+	w.record(Event{Kind: EventConnect, ID: session.ID.String(), Name: name, X: pl.X, Y: pl.Y})
+	pl.mu.Unlock()
+	w.sendWorld(session)
+	w.playerConnectedSend(session, pl, true, true)
 	w.sendPlayers(session)
+	w.sendChatBacklog(session)
+	w.sendItems(session)
+	w.sendInventory(session)
 	w.sendGarbage(session, true)
+	return nil
 }
 
 func (w *GameWorld) Disconnect(session *Session) {
+	if w.disconnectSpectator(session) {
+		return
+	}
+	w.pmu.Lock()
 	player, ok := w.Players[session]
 	if !ok {
+		w.pmu.Unlock()
 		return
 	}
 	delete(w.Players, session)
-	w.playerConnectedSend(session, player.Name, false, true)
+	w.pmu.Unlock()
+	w.Map.Remove(player)
+	w.record(Event{Kind: EventDisconnect, ID: session.ID.String(), Name: player.Name})
+	w.playerConnectedSend(session, player, false, true)
 }
 
 func (w *GameWorld) Reconnect(session *Session) {
+	if session.Spectator {
+		w.sendSpectatorState(session)
+		return
+	}
 	player, ok := w.Players[session]
 	if !ok {
 		return
 	}
+	session.Touch()
+	w.updatePresence(session, time.Now())
+
 	// Only send connect to the one joining
-	w.playerConnectedSend(session, player.Name, true, false)
+	w.sendWorld(session)
+	w.playerConnectedSend(session, player, true, false)
 	w.sendPlayers(session)
+	w.sendChatBacklog(session)
+	w.sendItems(session)
+	w.sendInventory(session)
 	w.sendGarbage(session, true)
 }
 
+// Broadcast
+// To every player and spectator.
 func (w *GameWorld) Broadcast(msg *capnp.Message, opcode uint16) {
-	w.pmu.RLock()
-	defer w.pmu.RUnlock()
-	for s := range w.Players {
-		_, _ = SendStream(w.writer, s.stream, msg, opcode)
+	w.broadcastPlayers(msg, opcode)
+	for _, s := range w.spectatorSessions() {
+		_, _ = s.Send(w.writer, msg, opcode)
 	}
 }
@@ -2,10 +2,9 @@ package backend
 
 import (
 	"bytes"
-	"crypto/sha1"
 	"errors"
-	"fmt"
 	"log"
+	"time"
 
 	"simpleWT/backend/cpnp"
 )
@@ -16,6 +15,7 @@ var (
 	ErrGameGarbageAmount   = errors.New("garbage amount invalid")
 	ErrGameGarbageData     = errors.New("garbage data invalid")
 	ErrGameGarbageMismatch = errors.New("garbage mismatch")
+	ErrGameGarbageBatch    = errors.New("garbage batch out of order")
 )
 
 func (w *GameWorld) connectOpcodes(s *Session) {
@@ -25,6 +25,31 @@ func (w *GameWorld) connectOpcodes(s *Session) {
 	s.AddHandler(OpCodeCChat, w.HandleClientChat)
 	s.AddHandler(OpCodeCMoved, w.HandleClientMoved)
 	s.AddHandler(OpCodeCGarbage, w.HandleClientGarbage)
+	s.AddHandler(OpCodeCChatHistory, w.HandleClientChatHistory)
+	s.AddHandler(OpCodeCMoveTo, w.HandleClientMoveTo)
+	s.AddHandler(OpCodeCPlayers, w.HandleClientPlayers)
+}
+
+// HandleClientPlayers
+// Sends the player list again so a client can check what it has.
+func (w *GameWorld) HandleClientPlayers(s *Session, payload []byte) {
+	_, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientPlayers)
+	if !valid {
+		return
+	}
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	allowed := p.resync.Allow(time.Now())
+	p.mu.Unlock()
+	if !allowed {
+		return
+	}
+	w.sendPlayers(s)
 }
 
 func (w *GameWorld) HandleClientChat(s *Session, payload []byte) {
@@ -32,6 +57,7 @@ func (w *GameWorld) HandleClientChat(s *Session, payload []byte) {
 	if !valid {
 		return
 	}
+	s.Touch()
 	if !msg.HasText() {
 		return
 	}
@@ -39,7 +65,46 @@ func (w *GameWorld) HandleClientChat(s *Session, payload []byte) {
 	if err != nil {
 		return
 	}
-	w.sendChat(s, txt)
+	target, err := msg.Target()
+	if err != nil {
+		return
+	}
+	w.handleChat(s, msg.Kind(), txt, target)
+}
+
+func (w *GameWorld) HandleClientChatHistory(s *Session, payload []byte) {
+	msg, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientChatHistory)
+	if !valid {
+		return
+	}
+	count := int(msg.Count())
+	if count <= 0 || count > w.config.Chat.HistoryPage {
+		count = w.config.Chat.HistoryPage
+	}
+	w.sendChatHistory(s, msg.Kind(), msg.Before(), count)
+}
+
+func (w *GameWorld) HandleClientMoveTo(s *Session, payload []byte) {
+	msg, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientMoveTo)
+	if !valid {
+		return
+	}
+	s.Touch()
+
+	err := w.MoveTo(s, int(msg.X()), int(msg.Y()))
+	if err != nil {
+		w.pmu.RLock()
+		p, ok := w.Players[s]
+		w.pmu.RUnlock()
+		if !ok {
+			return
+		}
+		w.cancelPath(s, p, cpnp.PathStatus_cancelled)
+		p.mu.Lock()
+		x, y := p.X, p.Y
+		p.mu.Unlock()
+		w.sendPathStep(s, x, y, 0, cpnp.PathStatus_noPath)
+	}
 }
 
 func (w *GameWorld) HandleClientMoved(s *Session, payload []byte) {
@@ -48,8 +113,20 @@ func (w *GameWorld) HandleClientMoved(s *Session, payload []byte) {
 		// Print invalid
 		return
 	}
+	s.Touch()
+
+	// Manual moves take over from MoveTo.
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if ok {
+		w.cancelPath(s, p, cpnp.PathStatus_cancelled)
+	}
 
-	w.movePlayer(s, msg.X(), msg.Y())
+	if !w.allowMove(s, msg.X(), msg.Y(), msg.Seq()) {
+		return
+	}
+	w.movePlayer(s, msg.X(), msg.Y(), msg.Seq())
 }
 
 func (w *GameWorld) HandleClientGarbage(s *Session, payload []byte) {
@@ -71,24 +148,57 @@ func (w *GameWorld) HandleClientGarbage(s *Session, payload []byte) {
 	if !ok {
 		return
 	}
+	p.mu.Lock()
+	policy := w.garbagePolicy(s)
+	cooling := !p.garbageCooldown.IsZero()
+	stale := msg.Challenge() != 0 && msg.Challenge() != p.garbageChallenge
+	p.mu.Unlock()
+	if cooling || stale {
+		// Done for now or sent before a new challenge,
+		// extra ones don't count either way.
+		return
+	}
 
-	needNew, err := p.HandleGarbage(txt)
+	p.mu.Lock()
+	before := p.GarbageTotal
+	p.mu.Unlock()
+	needNew, err := p.garbageBatch(msg.Batch())
+	if err == nil {
+		needNew, err = p.HandleGarbage(txt)
+	}
+	now := time.Now()
+	p.mu.Lock()
+	verified := max(before-p.GarbageTotal, 0)
+	started := p.garbageStarted
+	p.mu.Unlock()
+	s.recordGarbage(len(payload), verified, err, now)
+	w.record(Event{Kind: EventGarbage, ID: s.ID.String(), Name: p.Name, Ok: err == nil})
 	if err != nil {
-		log.Println("errored", err)
+		log.Printf("Error garbage from %s: %v\n", p.Name, err)
 		p.mu.Lock()
 		p.GarbageFailed++
-		if p.GarbageFailed > 5 {
-			log.Println("Failed 5 garbage requests, closing session.")
-			_ = s.Close()
-		}
+		action := p.garbageViolations.Add(policy.Escalation, time.Now())
 		p.mu.Unlock()
+		// Nothing to throttle, garbage goes out at the policy's pace either way.
+		if w.escalate(s, p, action, "garbage", nil) {
+			return
+		}
 		if needNew {
 			w.sendGarbage(s, true)
 		}
 		return
 	}
+	if needNew {
+		stats := s.recordChallenge(now.Sub(started))
+		log.Printf("Garbage challenge done by %s in %s: %s\n", p.Name, now.Sub(started).Round(time.Millisecond), stats)
+	}
 	p.mu.Lock()
 	p.GarbageFailed = 0
+	p.garbageViolations.Reset()
+	if needNew && policy.Cooldown > 0 {
+		p.garbageCooldown = time.Now().Add(policy.Cooldown)
+		needNew = false
+	}
 	p.mu.Unlock()
 	if needNew {
 		w.sendGarbage(s, false)
@@ -97,6 +207,23 @@ func (w *GameWorld) HandleClientGarbage(s *Session, payload []byte) {
 	}
 }
 
+// garbageBatch
+// Batches have to come in order, the stream keeps them that way.
+// 0 is whatever is next, for clients that don't count.
+func (p *Player) garbageBatch(batch uint32) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if batch == 0 {
+		batch = p.garbageBatches + 1
+	}
+	if batch != p.garbageBatches+1 {
+		log.Printf("Player %s, garbage batch %d != %d\n", p.Name, batch, p.garbageBatches+1)
+		return true, ErrGameGarbageBatch
+	}
+	p.garbageBatches = batch
+	return false, nil
+}
+
 func (p *Player) HandleGarbage(hashes cpnp.GarbageData_List) (bool, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -123,16 +250,22 @@ func (p *Player) HandleGarbage(hashes cpnp.GarbageData_List) (bool, error) {
 			log.Println("No data", err)
 			return true, err
 		}
-		// A sha1 hash is of len 20
-		if len(data) != 20 {
-			log.Println("hash length != 20", len(data))
+		if p.GarbageMode == cpnp.GarbageMode_work {
+			err = p.checkGarbageWork(data)
+			if err != nil {
+				return true, err
+			}
+			p.GarbageTotal--
+			continue
+		}
+		size := GarbageHashSize(p.GarbageHash)
+		if len(data) != size {
+			log.Printf("hash length %d != %d\n", len(data), size)
 			return true, ErrGameGarbageHash
 		}
 
-		// This is probably a horrible way to do this.
-		cur := fmt.Sprintf("%s%d", p.GarbageBase, i)
-		hash := sha1.Sum([]byte(cur))
-		if bytes.Equal(hash[:], data[:]) {
+		hash := GarbageDigest(p.GarbageHash, p.GarbageEncoding, p.GarbageBase[:], i)
+		if bytes.Equal(hash, data) {
 			p.GarbageTotal--
 		} else {
 			return true, ErrGameGarbageMismatch
@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+// MovementStats
+// Per player movement counters.
+type MovementStats struct {
+	Allowed int
+	Queued  int
+	Dropped int
+
+	// Violations current count, resets after the escalation window.
+	Violations      int
+	TotalViolations int
+	Throttled       bool
+}
+
+// queuedInput
+// A move that came in over the limit, handled on a later tick.
+type queuedInput struct {
+	x, y int8
+	seq  uint32
+}
+
+type queuedMoves struct {
+	s     *Session
+	moves []queuedInput
+}
+
+// allowMove
+// Checks the movement budget.
+// False means the move was queued or dropped and shouldn't be done now.
+func (w *GameWorld) allowMove(s *Session, x, y int8, seq uint32) bool {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	conf := w.config.Movement
+	now := time.Now()
+
+	p.mu.Lock()
+	// Anything queued goes first so inputs stay in order.
+	if len(p.moveQueue) == 0 && p.moveBudget.Allow(now) {
+		p.MoveStats.Allowed++
+		p.mu.Unlock()
+		return true
+	}
+	if len(p.moveQueue) < conf.Queue {
+		p.moveQueue = append(p.moveQueue, queuedInput{x, y, seq})
+		p.MoveStats.Queued++
+		p.mu.Unlock()
+		return false
+	}
+	p.MoveStats.Dropped++
+	action := p.moveViolations.Add(conf.Escalation, now)
+	p.MoveStats.Violations = p.moveViolations.Count
+	p.MoveStats.TotalViolations = p.moveViolations.Total
+	p.mu.Unlock()
+
+	w.escalate(s, p, action, "movement", w.throttleMovement)
+	return false
+}
+
+// tickMovement
+// Lets queued moves through as the budget refills and lifts throttles.
+func (w *GameWorld) tickMovement(now time.Time) {
+	conf := w.config.Movement
+	var ready []queuedMoves
+
+	w.pmu.RLock()
+	for s, p := range w.Players {
+		p.mu.Lock()
+		if p.moveThrottled && p.moveViolations.Expired(conf.Escalation, now) {
+			p.moveThrottled = false
+			p.MoveStats.Throttled = false
+			p.moveViolations.Reset()
+			p.MoveStats.Violations = 0
+			p.moveBudget.SetLimit(conf.Limit, now)
+		}
+
+		n := 0
+		for n < len(p.moveQueue) && p.moveBudget.Allow(now) {
+			n++
+		}
+		if n > 0 {
+			moves := make([]queuedInput, n)
+			copy(moves, p.moveQueue[:n])
+			p.moveQueue = p.moveQueue[n:]
+			p.MoveStats.Allowed += n
+			ready = append(ready, queuedMoves{s, moves})
+		}
+		p.mu.Unlock()
+	}
+	w.pmu.RUnlock()
+
+	for _, q := range ready {
+		for _, mv := range q.moves {
+			w.movePlayer(q.s, mv.x, mv.y, mv.seq)
+		}
+	}
+}
+
+// escalate
+// Does the action for a violation, what is just for the messages.
+// throttle slows down what the violations came from, nil warns instead.
+// Returns true if the player got kicked.
+func (w *GameWorld) escalate(s *Session, p *Player, action EscalationAction, what string, throttle func(*Player)) bool {
+	if action == EscalateThrottle && throttle == nil {
+		action = EscalateWarn
+	}
+	switch action {
+	case EscalateWarn:
+		w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Too many %s violations, slow down.", what))
+	case EscalateThrottle:
+		throttle(p)
+		w.sendNotice(s, cpnp.NoticeKind_throttled, fmt.Sprintf("Throttled for %s violations.", what))
+	case EscalateKick:
+		w.kick(s, fmt.Sprintf("Too many %s violations", what))
+		return true
+	}
+	return false
+}
+
+// throttleMovement
+// Drops the move budget to the throttled limit until the violations expire.
+func (w *GameWorld) throttleMovement(p *Player) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.moveThrottled = true
+	p.MoveStats.Throttled = true
+	p.moveBudget.SetLimit(w.config.Movement.Throttled, time.Now())
+}
+
+// kick
+// Tells the player why, then closes the session.
+func (w *GameWorld) kick(s *Session, reason string) {
+	log.Printf("Kicking %s: %s\n", s.ID, reason)
+	w.sendNotice(s, cpnp.NoticeKind_kicked, reason)
+	_ = s.CloseWithReason(reason)
+}
+
+func (w *GameWorld) sendNotice(s *Session, kind cpnp.NoticeKind, text string) {
+	err := QueueMessage(s, OpCodeSNotice, cpnp.NewRootGameServerNotice, func(msg cpnp.GameServerNotice) error {
+		msg.SetKind(kind)
+		return msg.SetText(text)
+	})
+	if err != nil {
+		log.Printf("Error sending notice packet: %v\n", err)
+	}
+}
+
+// MovementStats
+// Movement counters for a session, false if it isn't in the world.
+func (w *GameWorld) MovementStats(id uuid.UUID) (MovementStats, bool) {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	for s, p := range w.Players {
+		if s.ID != id {
+			continue
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.MoveStats, true
+	}
+	return MovementStats{}, false
+}
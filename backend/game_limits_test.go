@@ -0,0 +1,26 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestEscalateThrottle(t *testing.T) {
+	w := newGameWorld("test", NewDatabaseManager(), DefaultGameConfig(), nil)
+	s := testStart(NewSessionManager().CreateSession(uuid.Nil, "127.0.0.1", nil))
+	p := new(Player)
+
+	// Garbage has nothing of its own to throttle, moves stay as they were.
+	if w.escalate(s, p, EscalateThrottle, "garbage", nil) {
+		t.Fatal("kicked on throttle")
+	}
+	if p.MoveStats.Throttled || p.moveThrottled {
+		t.Error("garbage throttled movement")
+	}
+
+	w.escalate(s, p, EscalateThrottle, "movement", w.throttleMovement)
+	if !p.MoveStats.Throttled || !p.moveThrottled {
+		t.Error("movement not throttled")
+	}
+}
@@ -0,0 +1,293 @@
+package backend
+
+import (
+	"cmp"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// DefaultMapSize positions go from 0 to 100 and wrap around.
+const DefaultMapSize = 101
+
+// How many random cells to try before scanning for a free one.
+const spawnAttempts = 32
+
+var (
+	ErrMapFull     = errors.New("map full")
+	ErrMapOccupied = errors.New("cell occupied")
+)
+
+// Cell
+// A single spot on the map
+type Cell struct {
+	X, Y int
+}
+
+// GameMap
+// The grid players move on.
+// Keeps track of who is standing where, only enforced with Collision.
+// Walls are always enforced.
+type GameMap struct {
+	Width, Height int
+	Collision     bool
+
+	mu       sync.Mutex
+	occupied map[Cell]*Player
+	walls    map[Cell]struct{}
+
+	// rng for walls and spawns, Seed it to get the same map again.
+	rng *rand.Rand
+}
+
+func NewGameMap(width, height int, collision bool) *GameMap {
+	if width <= 0 {
+		width = DefaultMapSize
+	}
+	if height <= 0 {
+		height = DefaultMapSize
+	}
+	return &GameMap{
+		Width:     width,
+		Height:    height,
+		Collision: collision,
+		occupied:  make(map[Cell]*Player),
+		walls:     make(map[Cell]struct{}),
+		rng:       rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+}
+
+// Seed
+// Resets the map's random numbers, same seed same walls and spawns.
+func (m *GameMap) Seed(seed uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rng = rand.New(rand.NewPCG(seed, seed))
+}
+
+// RandomCell
+// Any cell on the map, from the map's seeded random numbers.
+func (m *GameMap) RandomCell() Cell {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return Cell{m.rng.IntN(m.Width), m.rng.IntN(m.Height)}
+}
+
+// AddWall
+// Puts a wall on a cell. Fails if someone is standing there.
+func (m *GameMap) AddWall(x, y int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	x, y = m.Wrap(x, y)
+	c := Cell{x, y}
+	if _, ok := m.occupied[c]; ok {
+		return ErrMapOccupied
+	}
+	m.walls[c] = struct{}{}
+	return nil
+}
+
+// RandomWalls
+// Scatters walls around the map.
+func (m *GameMap) RandomWalls(n int) {
+	n = min(n, m.Width*m.Height/2)
+	for range n {
+		c := m.RandomCell()
+		_ = m.AddWall(c.X, c.Y)
+	}
+}
+
+// Wall
+// If a cell is a wall.
+func (m *GameMap) Wall(x, y int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.walls[Cell{x, y}]
+	return ok
+}
+
+// Walls
+// All the walls on the map, row by row.
+func (m *GameMap) Walls() []Cell {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	walls := make([]Cell, 0, len(m.walls))
+	for c := range m.walls {
+		walls = append(walls, c)
+	}
+	slices.SortFunc(walls, func(a, b Cell) int {
+		if a.Y != b.Y {
+			return cmp.Compare(a.Y, b.Y)
+		}
+		return cmp.Compare(a.X, b.X)
+	})
+	return walls
+}
+
+// Wrap
+// Wraps a position around the edges of the map.
+func (m *GameMap) Wrap(x, y int) (int, int) {
+	x %= m.Width
+	if x < 0 {
+		x += m.Width
+	}
+	y %= m.Height
+	if y < 0 {
+		y += m.Height
+	}
+	return x, y
+}
+
+// Distance
+// Steps between two cells, diagonals count as one and edges wrap.
+func (m *GameMap) Distance(x1, y1, x2, y2 int) int {
+	dx := abs(x1 - x2)
+	dx = min(dx, m.Width-dx)
+	dy := abs(y1 - y2)
+	dy = min(dy, m.Height-dy)
+	return max(dx, dy)
+}
+
+// Toward
+// The step to take from one cell to get closer to another, edges wrap.
+func (m *GameMap) Toward(x1, y1, x2, y2 int) (dx, dy int) {
+	dx = ((x2-x1)%m.Width + m.Width) % m.Width
+	if dx > m.Width/2 {
+		dx -= m.Width
+	}
+	dy = ((y2-y1)%m.Height + m.Height) % m.Height
+	if dy > m.Height/2 {
+		dy -= m.Height
+	}
+	return sign(dx), sign(dy)
+}
+
+// At
+// Who is standing on a cell, nil if no one.
+func (m *GameMap) At(x, y int) *Player {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.occupied[Cell{x, y}]
+}
+
+// Free
+// If a player could stand on the cell.
+func (m *GameMap) Free(x, y int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.free(Cell{x, y}, nil)
+}
+
+// free expects m.mu to be held.
+func (m *GameMap) free(c Cell, self *Player) bool {
+	if _, ok := m.walls[c]; ok {
+		return false
+	}
+	if !m.Collision {
+		return true
+	}
+	who, ok := m.occupied[c]
+	return !ok || who == self
+}
+
+// Spawn
+// Places a player on a random cell, with collision on the cell is a free one.
+func (m *GameMap) Spawn(p *Player) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c, ok := m.randomFree(p)
+	if !ok {
+		return ErrMapFull
+	}
+	m.place(p, c)
+	return nil
+}
+
+// randomFree expects m.mu to be held.
+func (m *GameMap) randomFree(p *Player) (Cell, bool) {
+	for range spawnAttempts {
+		c := Cell{m.rng.IntN(m.Width), m.rng.IntN(m.Height)}
+		if m.free(c, p) {
+			return c, true
+		}
+	}
+	// Map is pretty full, just look for anything.
+	for y := range m.Height {
+		for x := range m.Width {
+			c := Cell{x, y}
+			if m.free(c, p) {
+				return c, true
+			}
+		}
+	}
+	return Cell{}, false
+}
+
+// Place
+// Puts a player at a position, fails if someone else is there.
+func (m *GameMap) Place(p *Player, x, y int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	x, y = m.Wrap(x, y)
+	c := Cell{x, y}
+	if !m.free(c, p) {
+		return ErrMapOccupied
+	}
+	m.place(p, c)
+	return nil
+}
+
+// Move
+// Moves a player by a step. Returns the position the player ends up at.
+// If the cell is a wall or taken the player doesn't move and ok is false.
+func (m *GameMap) Move(p *Player, dx, dy int) (x, y int, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p.mu.Lock()
+	ox, oy := p.X, p.Y
+	p.mu.Unlock()
+
+	nx, ny := m.Wrap(ox+dx, oy+dy)
+	c := Cell{nx, ny}
+	if !m.free(c, p) {
+		return ox, oy, false
+	}
+	m.place(p, c)
+	return nx, ny, true
+}
+
+// place expects m.mu to be held.
+func (m *GameMap) place(p *Player, c Cell) {
+	p.mu.Lock()
+	old := Cell{p.X, p.Y}
+	p.X = c.X
+	p.Y = c.Y
+	p.mu.Unlock()
+
+	if who, ok := m.occupied[old]; ok && who == p {
+		delete(m.occupied, old)
+	}
+	// Without collision someone else could already be here,
+	// last one in wins the lookup.
+	m.occupied[c] = p
+}
+
+// Remove
+// Takes a player off the map.
+func (m *GameMap) Remove(p *Player) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p.mu.Lock()
+	c := Cell{p.X, p.Y}
+	p.mu.Unlock()
+
+	if who, ok := m.occupied[c]; ok && who == p {
+		delete(m.occupied, c)
+	}
+}
@@ -0,0 +1,63 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMapWrap(t *testing.T) {
+	m := NewGameMap(0, 0, false)
+
+	x, y := m.Wrap(-1, DefaultMapSize)
+	if x != DefaultMapSize-1 || y != 0 {
+		t.Errorf("wrap got %d,%d", x, y)
+	}
+}
+
+func TestMapCollision(t *testing.T) {
+	m := NewGameMap(10, 10, true)
+
+	a := new(Player)
+	b := new(Player)
+	if err := m.Place(a, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Place(b, 1, 1); !errors.Is(err, ErrMapOccupied) {
+		t.Fatalf("expected occupied, got %v", err)
+	}
+	if err := m.Place(b, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	x, y, ok := m.Move(b, -1, 0)
+	if ok {
+		t.Fatal("moved into occupied cell")
+	}
+	if x != 2 || y != 1 {
+		t.Errorf("blocked move got %d,%d", x, y)
+	}
+
+	_, _, ok = m.Move(a, 0, 1)
+	if !ok {
+		t.Fatal("move into free cell failed")
+	}
+	if m.At(1, 1) != nil {
+		t.Error("old cell still occupied")
+	}
+	if _, _, ok = m.Move(b, -1, 0); !ok {
+		t.Fatal("move into freed cell failed")
+	}
+}
+
+func TestMapSpawnFull(t *testing.T) {
+	m := NewGameMap(2, 2, true)
+
+	for range 4 {
+		if err := m.Spawn(new(Player)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.Spawn(new(Player)); !errors.Is(err, ErrMapFull) {
+		t.Fatalf("expected full, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package backend
+
+import (
+	"log"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+// MoveTo
+// Plans a path for a player, the tick walks it.
+func (w *GameWorld) MoveTo(s *Session, x, y int) error {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return ErrMapNoPath
+	}
+
+	p.mu.Lock()
+	from := Cell{p.X, p.Y}
+	p.mu.Unlock()
+
+	conf := w.config.Movement
+	path, err := w.Map.Path(from, Cell{x, y}, p, conf.PathAvoidPlayers, conf.PathSearch)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.path = path
+	p.mu.Unlock()
+
+	status := cpnp.PathStatus_moving
+	if len(path) == 0 {
+		status = cpnp.PathStatus_arrived
+	}
+	w.sendPathStep(s, from.X, from.Y, len(path), status)
+	return nil
+}
+
+// cancelPath
+// Drops a path, the client only hears about it if there was one.
+func (w *GameWorld) cancelPath(s *Session, p *Player, status cpnp.PathStatus) {
+	p.mu.Lock()
+	had := len(p.path) > 0
+	p.path = nil
+	x, y := p.X, p.Y
+	p.mu.Unlock()
+	if had {
+		w.sendPathStep(s, x, y, 0, status)
+	}
+}
+
+// tickPaths
+// Takes a step along every path that has the movement budget for it.
+func (w *GameWorld) tickPaths(now time.Time) {
+	type step struct {
+		s  *Session
+		p  *Player
+		to Cell
+	}
+	var steps []step
+
+	w.pmu.RLock()
+	for s, p := range w.Players {
+		p.mu.Lock()
+		// Queued single steps get the budget first.
+		if len(p.path) > 0 && len(p.moveQueue) == 0 && p.moveBudget.Allow(now) {
+			steps = append(steps, step{s, p, p.path[0]})
+		}
+		p.mu.Unlock()
+	}
+	w.pmu.RUnlock()
+
+	for _, st := range steps {
+		st.p.mu.Lock()
+		x, y := st.p.X, st.p.Y
+		st.p.mu.Unlock()
+
+		dx, dy := w.Map.Toward(x, y, st.to.X, st.to.Y)
+		nx, ny, moved := w.Map.Move(st.p, dx, dy)
+		if !moved || (Cell{nx, ny}) != st.to {
+			w.cancelPath(st.s, st.p, cpnp.PathStatus_blocked)
+			continue
+		}
+
+		st.p.mu.Lock()
+		if len(st.p.path) > 0 {
+			st.p.path = st.p.path[1:]
+		}
+		remaining := len(st.p.path)
+		st.p.mu.Unlock()
+
+		w.moveSend(st.s.ID.String(), st.p.Name, nx, ny)
+		w.pickup(st.s, st.p, nx, ny, now)
+
+		status := cpnp.PathStatus_moving
+		if remaining == 0 {
+			status = cpnp.PathStatus_arrived
+		}
+		w.sendPathStep(st.s, nx, ny, remaining, status)
+	}
+}
+
+func (w *GameWorld) sendPathStep(s *Session, x, y, remaining int, status cpnp.PathStatus) {
+	err := QueueMessage(s, OpCodeSPathStep, cpnp.NewRootGameServerPathStep, func(msg cpnp.GameServerPathStep) error {
+		msg.SetX(int32(x))
+		msg.SetY(int32(y))
+		msg.SetRemaining(uint32(remaining))
+		msg.SetStatus(status)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error sending path step packet: %v\n", err)
+	}
+}
@@ -6,13 +6,26 @@ package backend
 import (
 	"crypto/sha1"
 	"log"
-	"math/rand/v2"
 	"time"
 
 	"simpleWT/backend/cpnp"
 )
 
-func (w *GameWorld) playerConnectedSend(session *Session, name string, connect, broadcast bool) {
+func (w *GameWorld) playerConnectedSend(session *Session, p *Player, connect, broadcast bool) {
+	to := session
+	if broadcast {
+		to = nil
+	}
+	p.mu.Lock()
+	name, x, y, presence := p.Name, p.X, p.Y, p.Presence
+	p.mu.Unlock()
+	w.connectedSend(to, session.ID.String(), name, x, y, presence, connect)
+}
+
+// connectedSend
+// Connect or disconnect for any entity, nil to is everyone.
+// Connects carry where it is so clients don't need to wait for a move.
+func (w *GameWorld) connectedSend(to *Session, id, name string, x, y int, presence cpnp.Presence, connect bool) {
 	w.writer.mu.Lock()
 	defer w.writer.mu.Unlock()
 	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastConnect)
@@ -28,38 +41,77 @@ func (w *GameWorld) playerConnectedSend(session *Session, name string, connect,
 		return
 	}
 
-	_ = pl.SetId(session.ID.String())
+	_ = pl.SetId(id)
 	_ = pl.SetName(name)
+	pl.SetX(int32(x))
+	pl.SetY(int32(y))
+	pl.SetPresence(presence)
 	_ = msg.SetPlayer(pl)
 
 	msg.SetConnected(connect)
 
-	if broadcast {
+	if to == nil {
 		w.Broadcast(msg.Message(), OpCodeBConnect)
 	} else {
-		session.writer.mu.Lock()
-		_, err = SendStream(w.writer, session.stream, msg.Message(), OpCodeBConnect)
-		session.writer.mu.Unlock()
+		to.writer.mu.Lock()
+		_, err = to.Send(w.writer, msg.Message(), OpCodeBConnect)
+		to.writer.mu.Unlock()
 		if err != nil {
 			log.Printf("Error sending packet: %v\n", err)
 		}
 	}
 }
 
-func (w *GameWorld) sendPlayers(s *Session) {
-	type player struct {
-		X, Y int
-		Name string
-		ID   string
+func (w *GameWorld) sendWorld(s *Session) {
+	walls := w.Map.Walls()
+	err := QueueMessage(s, OpCodeSWorld, cpnp.NewRootGameServerWorld, func(msg cpnp.GameServerWorld) error {
+		err := msg.SetName(w.Name)
+		if err != nil {
+			return err
+		}
+		msg.SetWidth(int32(w.Map.Width))
+		msg.SetHeight(int32(w.Map.Height))
+		list, err := msg.NewWalls(int32(len(walls)))
+		if err != nil {
+			return err
+		}
+		for i, c := range walls {
+			list.At(i).SetX(int32(c.X))
+			list.At(i).SetY(int32(c.Y))
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error sending world packet: %v\n", err)
 	}
-	var players []player
+}
+
+// PlayerList
+// Everyone in the world as the player list sends them.
+func (w *GameWorld) PlayerList() []WorldPlayer {
+	var players []WorldPlayer
 	w.pmu.RLock()
 	for s, p := range w.Players {
-		// Maybe lock the player?
-		players = append(players, player{p.X, p.Y, p.Name, s.ID.String()})
+		p.mu.Lock()
+		players = append(players, WorldPlayer{s.ID.String(), p.Name, p.X, p.Y, p.Presence})
+		p.mu.Unlock()
 	}
 	w.pmu.RUnlock()
 
+	// NPCs show up like anyone else.
+	w.nmu.RLock()
+	for id, n := range w.NPCs {
+		n.Player.mu.Lock()
+		players = append(players, WorldPlayer{id.String(), n.Player.Name, n.Player.X, n.Player.Y, n.Player.Presence})
+		n.Player.mu.Unlock()
+	}
+	w.nmu.RUnlock()
+	return players
+}
+
+func (w *GameWorld) sendPlayers(s *Session) {
+	players := w.PlayerList()
+
 	w.writer.mu.Lock()
 	defer w.writer.mu.Unlock()
 
@@ -79,6 +131,7 @@ func (w *GameWorld) sendPlayers(s *Session) {
 		currPlayer.SetX(int32(players[i].X))
 		currPlayer.SetY(int32(players[i].Y))
 		_ = currPlayer.SetId(players[i].ID)
+		currPlayer.SetPresence(players[i].Presence)
 	}
 	err = msg.SetPlayers(pList)
 	if err != nil {
@@ -86,13 +139,16 @@ func (w *GameWorld) sendPlayers(s *Session) {
 		return
 	}
 
-	_, err = SendStream(w.writer, s.stream, msg.Message(), OpCodeSPlayers)
+	_, err = s.Send(w.writer, msg.Message(), OpCodeSPlayers)
 	if err != nil {
 		log.Printf("Error sending players packet: %v\n", err)
 	}
 }
 
-func (w *GameWorld) movePlayer(s *Session, x, y int8) {
+// movePlayer
+// seq is the clients input number, 0 if the client doesn't send them.
+// Inputs with a seq get acked with where the server has the player.
+func (w *GameWorld) movePlayer(s *Session, x, y int8, seq uint32) {
 	if s == nil {
 		return
 	}
@@ -103,6 +159,18 @@ func (w *GameWorld) movePlayer(s *Session, x, y int8) {
 		log.Println("Player not found")
 		return
 	}
+
+	if seq != 0 {
+		p.mu.Lock()
+		stale := seq <= p.LastInput
+		if !stale {
+			p.LastInput = seq
+		}
+		p.mu.Unlock()
+		if stale {
+			return
+		}
+	}
 	// Probably a better way to do this math.
 	// I'm tired though, and it's not a big deal.
 	xx := 0
@@ -117,29 +185,39 @@ func (w *GameWorld) movePlayer(s *Session, x, y int8) {
 	} else if y < 0 {
 		yy = -1
 	}
-	nx := p.X + xx
-	ny := p.Y + yy
-	if nx > 100 {
-		nx = 0
-	} else if nx < 0 {
-		nx = 100
-	}
-	if ny > 100 {
-		ny = 0
-	} else if ny < 0 {
-		ny = 100
-	}
-
-	w.Players[s].mu.Lock()
-	w.Players[s].X = nx
-	w.Players[s].Y = ny
-	w.Players[s].mu.Unlock()
 
 	if xx == 0 && yy == 0 {
 		log.Println("Player not moving")
+		if seq != 0 {
+			p.mu.Lock()
+			px, py := p.X, p.Y
+			p.mu.Unlock()
+			w.sendMoveAck(s, seq, px, py)
+		}
 		return
 	}
 
+	nx, ny, moved := w.Map.Move(p, xx, yy)
+	if seq != 0 {
+		// The ack has the position, no need for a correction.
+		w.sendMoveAck(s, seq, nx, ny)
+	} else if !moved {
+		// Someone is in the way, tell the mover where they actually are.
+		w.sendMoveCorrection(s, nx, ny)
+	}
+	if !moved {
+		return
+	}
+	w.moveSend(s.ID.String(), p.Name, nx, ny)
+	w.pickup(s, p, nx, ny, time.Now())
+}
+
+// moveSend
+// Broadcasts where an entity moved to.
+func (w *GameWorld) moveSend(id, name string, x, y int) {
+	w.record(Event{Kind: EventMove, ID: id, Name: name, X: x, Y: y})
+	watchers := w.spectatorsSeeing(x, y)
+
 	// Broadcast so lock the gameworld writer
 	w.writer.mu.Lock()
 	defer w.writer.mu.Unlock()
@@ -157,46 +235,39 @@ func (w *GameWorld) movePlayer(s *Session, x, y int8) {
 		return
 	}
 
-	_ = who.SetId(s.ID.String())
-	_ = who.SetName(p.Name)
-	who.SetX(int32(p.X))
-	who.SetY(int32(p.Y))
+	_ = who.SetId(id)
+	_ = who.SetName(name)
+	who.SetX(int32(x))
+	who.SetY(int32(y))
 
 	_ = msg.SetWho(who)
-	w.Broadcast(msg.Message(), OpCodeBPlayerMoved)
-}
-
-func (w *GameWorld) sendChat(s *Session, text string) {
-	w.pmu.RLock()
-	p, ok := w.Players[s]
-	w.pmu.RUnlock()
-	if !ok {
-		return
-	}
-
-	// Broadcast so lock the game world writer
-	w.writer.mu.Lock()
-	defer w.writer.mu.Unlock()
-
-	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastChat)
-	if err != nil {
-		log.Printf("Error creating broadcast packet: %v", err)
-		return
+	w.broadcastPlayers(msg.Message(), OpCodeBPlayerMoved)
+	for _, s := range watchers {
+		_, _ = s.Send(w.writer, msg.Message(), OpCodeBPlayerMoved)
 	}
+}
 
-	err = msg.SetText(text)
+func (w *GameWorld) sendMoveCorrection(s *Session, x, y int) {
+	err := QueueMessage(s, OpCodeSMoveCorrection, cpnp.NewRootGameServerMoveCorrection, func(msg cpnp.GameServerMoveCorrection) error {
+		msg.SetX(int32(x))
+		msg.SetY(int32(y))
+		return nil
+	})
 	if err != nil {
-		log.Printf("Error creating broadcast packet: %v", err)
-		return
+		log.Printf("Error sending move correction packet: %v\n", err)
 	}
+}
 
-	err = msg.SetName(p.Name)
+func (w *GameWorld) sendMoveAck(s *Session, seq uint32, x, y int) {
+	err := QueueMessage(s, OpCodeSMoveAck, cpnp.NewRootGameServerMoveAck, func(msg cpnp.GameServerMoveAck) error {
+		msg.SetSeq(seq)
+		msg.SetX(int32(x))
+		msg.SetY(int32(y))
+		return nil
+	})
 	if err != nil {
-		log.Printf("Error creating broadcast packet: %v", err)
-		return
+		log.Printf("Error sending move ack packet: %v\n", err)
 	}
-
-	w.Broadcast(msg.Message(), OpCodeBChat)
 }
 
 func (w *GameWorld) sendGarbage(s *Session, reset bool) {
@@ -220,11 +291,24 @@ func (w *GameWorld) sendGarbage(s *Session, reset bool) {
 		return
 	}
 
-	// Creating a random amount of garbage
-	sec := 10 + rand.IntN(10)
-	per := rand.IntN(60) + 60
-	p.GarbageAmount = rand.IntN(10) + 10
-	p.GarbageTotal = per * sec * p.GarbageAmount
+	policy := w.garbagePolicy(s)
+	if !policy.Enabled() {
+		p.GarbageAmount = 0
+		p.GarbageTotal = 0
+		return
+	}
+	c := policy.Pick(s, w.garbageScale())
+	p.GarbageAmount = c.Amount
+	p.GarbageTotal = c.Total()
+	p.GarbageHash = c.Hash
+	p.GarbageEncoding = c.Encoding
+	p.GarbageMode = c.Mode
+	p.GarbageDifficulty = c.Difficulty
+	p.garbageNonce = 0
+	p.garbageChallenge++
+	p.garbageBatches = 0
+	p.garbageStarted = time.Now()
+	p.garbageCooldown = time.Time{}
 	p.GarbageBase = sha1.Sum([]byte(time.Now().Format(time.RFC3339)))
 
 	// Lock the writer
@@ -237,16 +321,23 @@ func (w *GameWorld) sendGarbage(s *Session, reset bool) {
 		return
 	}
 	msg.SetAmount(uint32(p.GarbageAmount))
-	// msg.SetSeconds(uint32(sec))
-	msg.SetPer(uint8(per))
-	// log.Printf("Requesting %s: %d/%ds for %ds total of %d base len %d", p.Name, p.GarbageAmount, per, sec, p.GarbageTotal, len(p.GarbageBase))
+	// msg.SetSeconds(uint32(c.Seconds))
+	msg.SetPer(uint8(c.Rate))
+	msg.SetHash(c.Hash)
+	msg.SetEncoding(c.Encoding)
+	msg.SetMode(c.Mode)
+	msg.SetDifficulty(uint8(c.Difficulty))
+	msg.SetChallenge(p.garbageChallenge)
+	msg.SetBatches(uint32(c.Batches()))
+	msg.SetWindow(uint16(c.Window))
+	// log.Printf("Requesting %s: %d/%ds for %ds total of %d base len %d", p.Name, p.GarbageAmount, c.Rate, c.Seconds, p.GarbageTotal, len(p.GarbageBase))
 	err = msg.SetBase(p.GarbageBase[:])
 	if err != nil {
 		log.Printf("Error making garbage packet: %v", err)
 		return
 	}
 
-	_, err = SendStream(s.writer, s.stream, msg.Message(), OpCodeSGarbage)
+	_, err = s.Send(s.writer, msg.Message(), OpCodeSGarbage)
 	if err != nil {
 		log.Printf("Error sending garbage packet: %v\n", err)
 	}
@@ -265,6 +356,8 @@ func (w *GameWorld) sendGarbageAck(s *Session) {
 	// Read player
 	p.mu.Lock()
 	gTotal := p.GarbageTotal
+	batch := p.garbageBatches
+	challenge := p.garbageChallenge
 	p.mu.Unlock()
 
 	// Lock the writer
@@ -276,10 +369,11 @@ func (w *GameWorld) sendGarbageAck(s *Session) {
 		log.Printf("Error making garbage ack packet: %v", err)
 		return
 	}
-	// This should probably be its own-received value not the total left.
-	msg.SetAck(uint32(gTotal))
+	msg.SetAck(batch)
+	msg.SetRemaining(uint32(max(gTotal, 0)))
+	msg.SetChallenge(challenge)
 
-	_, err = SendStream(s.writer, s.stream, msg.Message(), OpCodeSGarbageAck)
+	_, err = s.Send(s.writer, msg.Message(), OpCodeSGarbageAck)
 	if err != nil {
 		log.Printf("Error sending garbage ack packet: %v\n", err)
 	}
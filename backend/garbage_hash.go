@@ -0,0 +1,183 @@
+package backend
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrGarbageMode = errors.New("unknown garbage mode")
+)
+
+var (
+	// GarbageHashes every hash both sides know, cheapest first.
+	GarbageHashes = []cpnp.GarbageHash{
+		cpnp.GarbageHash_sha1,
+		cpnp.GarbageHash_sha256,
+		cpnp.GarbageHash_blake2b,
+		cpnp.GarbageHash_sha3,
+		cpnp.GarbageHash_sha512,
+	}
+	// GarbageEncodings every encoding both sides know.
+	GarbageEncodings = []cpnp.GarbageEncoding{
+		cpnp.GarbageEncoding_text,
+		cpnp.GarbageEncoding_hex,
+		cpnp.GarbageEncoding_binary,
+	}
+	// GarbageModes every mode both sides know.
+	GarbageModes = []cpnp.GarbageMode{
+		cpnp.GarbageMode_recompute,
+		cpnp.GarbageMode_work,
+	}
+)
+
+// GarbageHashSize
+// Bytes in a hash, 0 if unknown.
+func GarbageHashSize(h cpnp.GarbageHash) int {
+	switch h {
+	case cpnp.GarbageHash_sha1:
+		return sha1.Size
+	case cpnp.GarbageHash_sha256:
+		return sha256.Size
+	case cpnp.GarbageHash_sha512:
+		return sha512.Size
+	case cpnp.GarbageHash_sha3:
+		return 32
+	case cpnp.GarbageHash_blake2b:
+		return blake2b.Size256
+	default:
+		return 0
+	}
+}
+
+// GarbageDigest
+// The hash for message N of a challenge.
+func GarbageDigest(h cpnp.GarbageHash, enc cpnp.GarbageEncoding, base []byte, n int) []byte {
+	return garbageSum(h, garbageInput(enc, base, n))
+}
+
+func garbageSum(h cpnp.GarbageHash, in []byte) []byte {
+	switch h {
+	case cpnp.GarbageHash_sha256:
+		sum := sha256.Sum256(in)
+		return sum[:]
+	case cpnp.GarbageHash_sha512:
+		sum := sha512.Sum512(in)
+		return sum[:]
+	case cpnp.GarbageHash_sha3:
+		sum := sha3.Sum256(in)
+		return sum[:]
+	case cpnp.GarbageHash_blake2b:
+		sum := blake2b.Sum256(in)
+		return sum[:]
+	default:
+		sum := sha1.Sum(in)
+		return sum[:]
+	}
+}
+
+// garbageInput
+// What gets hashed for message N.
+func garbageInput(enc cpnp.GarbageEncoding, base []byte, n int) []byte {
+	switch enc {
+	case cpnp.GarbageEncoding_hex:
+		in := make([]byte, 0, hex.EncodedLen(len(base))+10)
+		in = hex.AppendEncode(in, base)
+		return strconv.AppendInt(in, int64(n), 10)
+	case cpnp.GarbageEncoding_binary:
+		in := make([]byte, 0, len(base)+4)
+		in = append(in, base...)
+		return binary.BigEndian.AppendUint32(in, uint32(n))
+	default:
+		// Same as fmt.Sprintf("%s%d", base, n)
+		in := make([]byte, 0, len(base)+10)
+		in = append(in, base...)
+		return strconv.AppendInt(in, int64(n), 10)
+	}
+}
+
+// ParseGarbageHashes
+// Comma separated hash names, unknown ones are skipped.
+func ParseGarbageHashes(s string) []cpnp.GarbageHash {
+	return parseNames(s, cpnp.GarbageHashFromString)
+}
+
+// ParseGarbageEncodings
+// Comma separated encoding names, unknown ones are skipped.
+func ParseGarbageEncodings(s string) []cpnp.GarbageEncoding {
+	return parseNames(s, cpnp.GarbageEncodingFromString)
+}
+
+// ParseGarbageModes
+// Comma separated mode names, unknown ones are skipped.
+func ParseGarbageModes(s string) []cpnp.GarbageMode {
+	return parseNames(s, cpnp.GarbageModeFromString)
+}
+
+// ParseGarbageMode
+// One mode name for the server, empty is recompute.
+func ParseGarbageMode(s string) (cpnp.GarbageMode, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" {
+		return cpnp.GarbageMode_recompute, nil
+	}
+	mode := cpnp.GarbageModeFromString(name)
+	if mode.String() != name {
+		return 0, fmt.Errorf("%w: %q", ErrGarbageMode, s)
+	}
+	return mode, nil
+}
+
+// parseNames
+// Enum values from comma separated names, no repeats.
+func parseNames[T interface {
+	comparable
+	String() string
+}](s string, fromString func(string) T) []T {
+	var values []T
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		v := fromString(name)
+		if v.String() == name && !slices.Contains(values, v) {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// joinNames
+// Comma separated, for the login query.
+func joinNames[T interface{ String() string }](values []T) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = v.String()
+	}
+	return strings.Join(names, ",")
+}
+
+// negotiate
+// First of the server's picks the client supports.
+// Everyone supports the zero value, so that is the fallback.
+// A nil supported list is an old client that only knows the zero value.
+func negotiate[T comparable](prefer, supported []T) T {
+	for _, v := range prefer {
+		if slices.Contains(supported, v) {
+			return v
+		}
+	}
+	var zero T
+	return zero
+}
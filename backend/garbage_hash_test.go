@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"simpleWT/backend/cpnp"
+)
+
+func TestGarbageDigest(t *testing.T) {
+	base := []byte("some base")
+	// The original protocol still works.
+	old := sha1.Sum([]byte(fmt.Sprintf("%s%d", base, 42)))
+	if got := GarbageDigest(cpnp.GarbageHash_sha1, cpnp.GarbageEncoding_text, base, 42); !slices.Equal(got, old[:]) {
+		t.Error("sha1 text doesn't match the original")
+	}
+
+	for _, h := range GarbageHashes {
+		seen := map[string]bool{}
+		for _, e := range GarbageEncodings {
+			d := GarbageDigest(h, e, base, 7)
+			if len(d) != GarbageHashSize(h) {
+				t.Errorf("%s %s: %d bytes, want %d", h, e, len(d), GarbageHashSize(h))
+			}
+			seen[string(d)] = true
+		}
+		if len(seen) != len(GarbageEncodings) {
+			t.Errorf("%s: encodings should hash differently", h)
+		}
+	}
+}
+
+func TestGarbageNegotiate(t *testing.T) {
+	got := ParseGarbageHashes(" SHA256, nope,blake2b,sha256")
+	if !slices.Equal(got, []cpnp.GarbageHash{cpnp.GarbageHash_sha256, cpnp.GarbageHash_blake2b}) {
+		t.Errorf("parsed %v", got)
+	}
+
+	prefer := []cpnp.GarbageHash{cpnp.GarbageHash_sha512, cpnp.GarbageHash_blake2b}
+	if h := negotiate(prefer, got); h != cpnp.GarbageHash_blake2b {
+		t.Errorf("negotiated %s", h)
+	}
+	if h := negotiate(prefer, nil); h != cpnp.GarbageHash_sha1 {
+		t.Errorf("old client negotiated %s", h)
+	}
+
+	// The default policy offers everything, not just the fallback.
+	s := &Session{
+		GarbageHashes:    []cpnp.GarbageHash{cpnp.GarbageHash_sha256},
+		GarbageEncodings: []cpnp.GarbageEncoding{cpnp.GarbageEncoding_hex},
+	}
+	if c := DefaultGarbagePolicy().Pick(s, 1); c.Hash != cpnp.GarbageHash_sha256 || c.Encoding != cpnp.GarbageEncoding_hex {
+		t.Errorf("default policy picked %s %s", c.Hash, c.Encoding)
+	}
+}
+
+func TestHandleGarbageHash(t *testing.T) {
+	p := &Player{
+		GarbageAmount:   2,
+		GarbageTotal:    4,
+		GarbageHash:     cpnp.GarbageHash_sha512,
+		GarbageEncoding: cpnp.GarbageEncoding_binary,
+	}
+	writer := NewPacketWriter()
+	build := func(h cpnp.GarbageHash) cpnp.GarbageData_List {
+		msg, err := NewMessage(writer, cpnp.NewRootGameClientGarbage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		list, err := msg.NewHash(int32(p.GarbageAmount))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range list.Len() {
+			_ = list.At(i).SetData(GarbageDigest(h, p.GarbageEncoding, p.GarbageBase[:], i))
+		}
+		return list
+	}
+
+	if _, err := p.HandleGarbage(build(cpnp.GarbageHash_sha1)); !errors.Is(err, ErrGameGarbageHash) {
+		t.Errorf("wrong hash should fail on size, got %v", err)
+	}
+	if done, err := p.HandleGarbage(build(cpnp.GarbageHash_sha512)); err != nil || done {
+		t.Errorf("first message: %v %v", done, err)
+	}
+	if done, err := p.HandleGarbage(build(cpnp.GarbageHash_sha512)); err != nil || !done {
+		t.Errorf("second message: %v %v", done, err)
+	}
+}
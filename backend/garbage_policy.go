@@ -0,0 +1,213 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrRangeInvalid = errors.New("range invalid")
+)
+
+// Range
+// A number between Min and Max, both included.
+// Max below Min is always Min, so Range{Min: 5} is a fixed 5.
+type Range struct {
+	Min, Max int
+}
+
+// Fixed
+// A Range that is always n.
+func Fixed(n int) Range {
+	return Range{Min: n, Max: n}
+}
+
+// Pick
+// A random number in the range.
+func (r Range) Pick() int {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rand.IntN(r.Max-r.Min+1)
+}
+
+func (r Range) String() string {
+	if r.Max <= r.Min {
+		return strconv.Itoa(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// ParseRange
+// "10" or "10-20".
+func ParseRange(s string) (Range, error) {
+	lo, hi, ranged := strings.Cut(strings.TrimSpace(s), "-")
+	low, err := strconv.Atoi(lo)
+	if err != nil || low < 0 {
+		return Range{}, fmt.Errorf("%w: %q", ErrRangeInvalid, s)
+	}
+	if !ranged {
+		return Fixed(low), nil
+	}
+	high, err := strconv.Atoi(hi)
+	if err != nil || high < low {
+		return Range{}, fmt.Errorf("%w: %q", ErrRangeInvalid, s)
+	}
+	return Range{Min: low, Max: high}, nil
+}
+
+// GarbagePolicy
+// What garbage challenges a player gets and what happens when they fail them.
+// A zero Amount turns garbage off.
+type GarbagePolicy struct {
+	// Amount hashes per message.
+	Amount Range
+	// Rate messages per second, at most 255.
+	Rate Range
+	// Duration seconds of messages per challenge.
+	Duration Range
+
+	// Hashes and Encodings the server will ask for, best first.
+	// The first one the client supports gets used, falling back to sha1 and text.
+	Hashes    []cpnp.GarbageHash
+	Encodings []cpnp.GarbageEncoding
+
+	// Mode work makes clients find nonces instead of recomputing hashes,
+	// only for clients that support it. Amount is then nonces per message.
+	Mode cpnp.GarbageMode
+	// Difficulty leading zero bits per nonce in work mode, sessions can override it.
+	Difficulty Range
+
+	// Escalation failed messages to warn, throttle and kick at.
+	Escalation Escalation
+
+	// Cooldown wait after a challenge is done before the next one.
+	Cooldown time.Duration
+
+	// Window batches a client can have sent without an ack, 1 is stop and wait.
+	Window int
+}
+
+// DefaultGarbagePolicy
+// The ranges the server always picked from.
+func DefaultGarbagePolicy() GarbagePolicy {
+	return GarbagePolicy{
+		Amount:   Range{10, 19},
+		Rate:     Range{60, 119},
+		Duration: Range{10, 19},
+		// Used to be hardcoded to more than 5 fails.
+		Escalation: Escalation{Kick: 6},
+		Window:     4,
+		// Everything both sides know, so clients that can't do sha1 or text still get a match.
+		Hashes:    slices.Clone(GarbageHashes),
+		Encodings: slices.Clone(GarbageEncodings),
+	}
+}
+
+// Enabled
+// If the policy sends any garbage.
+func (g GarbagePolicy) Enabled() bool {
+	return max(g.Amount.Min, g.Amount.Max) > 0 && max(g.Rate.Min, g.Rate.Max) > 0
+}
+
+// garbageChallenge
+// One round picked from a policy.
+type garbageChallenge struct {
+	Amount  int
+	Rate    int
+	Seconds int
+
+	Hash     cpnp.GarbageHash
+	Encoding cpnp.GarbageEncoding
+
+	Mode       cpnp.GarbageMode
+	Difficulty int
+
+	Window int
+}
+
+// Pick
+// Picks the numbers for the next challenge for a session.
+// Amount and rate get multiplied by scale, see GarbageController.
+func (g GarbagePolicy) Pick(s *Session, scale float64) garbageChallenge {
+	difficulty := s.GarbageDifficulty()
+	if difficulty == 0 {
+		difficulty = g.Difficulty.Pick()
+	}
+	return garbageChallenge{
+		Amount:  max(scaleRange(g.Amount, scale).Pick(), 1),
+		Rate:    min(max(scaleRange(g.Rate, scale).Pick(), 1), 255),
+		Seconds: max(g.Duration.Pick(), 1),
+
+		Hash:     negotiate(g.Hashes, s.GarbageHashes),
+		Encoding: negotiate(g.Encodings, s.GarbageEncodings),
+
+		Mode:       negotiate([]cpnp.GarbageMode{g.Mode}, s.GarbageModes),
+		Difficulty: min(max(difficulty, 0), maxGarbageDifficulty),
+
+		Window: min(max(g.Window, 1), math.MaxUint16),
+	}
+}
+
+// Total
+// Hashes needed to finish the challenge.
+func (c garbageChallenge) Total() int {
+	return c.Amount * c.Batches()
+}
+
+// Batches
+// Messages needed to finish the challenge.
+func (c garbageChallenge) Batches() int {
+	return c.Rate * c.Seconds
+}
+
+// garbagePolicy
+// The policy for a player, session override then user override then the world one.
+func (w *GameWorld) garbagePolicy(s *Session) GarbagePolicy {
+	if g := s.GarbagePolicy(); g != nil {
+		return *g
+	}
+	if w.manager != nil {
+		if g, ok := w.manager.garbageUsers[s.ID]; ok {
+			return g
+		}
+	}
+	return w.config.Garbage
+}
+
+// garbageScale
+// From the manager's controller, 1 without one.
+func (w *GameWorld) garbageScale() float64 {
+	if w.manager == nil {
+		return 1
+	}
+	return w.manager.Garbage.Scale()
+}
+
+// tickGarbage
+// Sends new challenges to players whose cooldown is over.
+func (w *GameWorld) tickGarbage(now time.Time) {
+	var due []*Session
+	w.pmu.RLock()
+	for s, p := range w.Players {
+		p.mu.Lock()
+		if !p.garbageCooldown.IsZero() && !now.Before(p.garbageCooldown) {
+			p.garbageCooldown = time.Time{}
+			due = append(due, s)
+		}
+		p.mu.Unlock()
+	}
+	w.pmu.RUnlock()
+
+	for _, s := range due {
+		w.sendGarbage(s, true)
+	}
+}
@@ -0,0 +1,113 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Range
+		err  error
+	}{
+		{"10", Fixed(10), nil},
+		{" 10-20 ", Range{10, 20}, nil},
+		{"20-10", Range{}, ErrRangeInvalid},
+		{"-5", Range{}, ErrRangeInvalid},
+		{"x", Range{}, ErrRangeInvalid},
+	}
+	for _, tt := range tests {
+		got, err := ParseRange(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("ParseRange(%q) = %v, %v want %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+	for range 100 {
+		if n := (Range{3, 5}).Pick(); n < 3 || n > 5 {
+			t.Fatalf("picked %d out of 3-5", n)
+		}
+	}
+}
+
+func TestGarbagePolicy(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Garbage = GarbagePolicy{Amount: Fixed(2), Rate: Fixed(3), Duration: Fixed(4), Cooldown: time.Second}
+		c.GarbageUsers = map[string]GarbagePolicy{"no garbage": {}}
+	})
+	w := g.w
+
+	s := g.join(t, "")
+	p := w.Players[s]
+	if p.GarbageAmount != 2 || p.GarbageTotal != 2*3*4 {
+		t.Errorf("global policy: amount %d total %d", p.GarbageAmount, p.GarbageTotal)
+	}
+
+	// Session beats the world policy.
+	s.SetGarbagePolicy(&GarbagePolicy{Amount: Fixed(1), Rate: Fixed(1), Duration: Fixed(1)})
+	p.garbageCooldown = time.Now().Add(-time.Millisecond)
+	w.tickGarbage(time.Now())
+	if p.GarbageAmount != 1 || p.GarbageTotal != 1 || !p.garbageCooldown.IsZero() {
+		t.Errorf("session policy: amount %d total %d", p.GarbageAmount, p.GarbageTotal)
+	}
+
+	// Still cooling down, nothing new.
+	s.SetGarbagePolicy(nil)
+	p.garbageCooldown = time.Now().Add(time.Minute)
+	w.tickGarbage(time.Now())
+	if p.GarbageAmount != 1 {
+		t.Error("challenge sent during cooldown")
+	}
+
+	// User override turns it off, the name is reserved for it.
+	n := testSession(t, g.db, g.sessions)
+	if _, _, err := g.db.RenameUser(n.ID, "No Garbage"); !errors.Is(err, ErrNameTaken) {
+		t.Fatalf("renamed into an override: %v", err)
+	}
+	uid, err := g.db.GetUser("no garbage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	n = testStart(g.sessions.CreateSession(uid, "127.0.0.1", nil))
+	if err := g.worlds.Connect(n, ""); err != nil {
+		t.Fatal(err)
+	}
+	if p := w.Players[n]; p.GarbageAmount != 0 || p.GarbageTotal != 0 {
+		t.Errorf("user policy: amount %d total %d", p.GarbageAmount, p.GarbageTotal)
+	}
+}
+
+func TestGarbageBatch(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Garbage = GarbagePolicy{Amount: Fixed(1), Rate: Fixed(2), Duration: Fixed(3), Window: 4}
+	})
+	w := g.w
+
+	s := g.join(t, "")
+	p := w.Players[s]
+	if p.garbageChallenge != 1 || p.garbageBatches != 0 {
+		t.Fatalf("challenge %d batch %d", p.garbageChallenge, p.garbageBatches)
+	}
+
+	// In order, or 0 for next.
+	for _, b := range []uint32{1, 2, 0} {
+		if _, err := p.garbageBatch(b); err != nil {
+			t.Fatalf("batch %d: %v", b, err)
+		}
+	}
+	if p.garbageBatches != 3 {
+		t.Errorf("at batch %d", p.garbageBatches)
+	}
+	if _, err := p.garbageBatch(3); !errors.Is(err, ErrGameGarbageBatch) {
+		t.Errorf("repeated batch: %v", err)
+	}
+	if _, err := p.garbageBatch(9); !errors.Is(err, ErrGameGarbageBatch) {
+		t.Errorf("skipped batch: %v", err)
+	}
+
+	w.sendGarbage(s, true)
+	if p.garbageChallenge != 2 || p.garbageBatches != 0 {
+		t.Errorf("new challenge %d batch %d", p.garbageChallenge, p.garbageBatches)
+	}
+}
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// garbageErrors
+// Failures get counted under the first of these they match.
+var garbageErrors = []error{
+	ErrGameGarbageInvalid,
+	ErrGameGarbageHash,
+	ErrGameGarbageAmount,
+	ErrGameGarbageData,
+	ErrGameGarbageMismatch,
+	ErrGameGarbageBatch,
+	ErrGameGarbageWork,
+	ErrGameGarbageNonce,
+}
+
+// GarbageStats
+// Garbage counters for a session, kept across worlds.
+type GarbageStats struct {
+	// Hashes verified, nonces in work mode.
+	Hashes int `json:"hashes"`
+	// Bytes of garbage payloads received.
+	Bytes   int `json:"bytes"`
+	Batches int `json:"batches"`
+
+	// Challenges finished and how long they took.
+	Challenges    int           `json:"challenges"`
+	LastChallenge time.Duration `json:"lastChallenge"`
+	ChallengeTime time.Duration `json:"challengeTime"`
+
+	// Failures by error, anything unexpected is "other".
+	Failures map[string]int `json:"failures"`
+
+	// First and Last batch received.
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// BatchesPerSecond
+// Between the first and last batch.
+func (g GarbageStats) BatchesPerSecond() float64 {
+	d := g.Last.Sub(g.First).Seconds()
+	if d <= 0 {
+		return 0
+	}
+	return float64(g.Batches) / d
+}
+
+// AverageChallenge
+// Mean time to finish a challenge.
+func (g GarbageStats) AverageChallenge() time.Duration {
+	if g.Challenges == 0 {
+		return 0
+	}
+	return g.ChallengeTime / time.Duration(g.Challenges)
+}
+
+// FailureCount
+// Every failure no matter the error.
+func (g GarbageStats) FailureCount() int {
+	n := 0
+	for _, c := range g.Failures {
+		n += c
+	}
+	return n
+}
+
+func (g GarbageStats) String() string {
+	return fmt.Sprintf("%d batches (%.1f/s), %d hashes, %d bytes, %d challenges avg %s, %d failures",
+		g.Batches, g.BatchesPerSecond(), g.Hashes, g.Bytes, g.Challenges, g.AverageChallenge().Round(time.Millisecond), g.FailureCount())
+}
+
+// garbageErrorName
+// Which count a failure goes under.
+func garbageErrorName(err error) string {
+	for _, e := range garbageErrors {
+		if errors.Is(err, e) {
+			return e.Error()
+		}
+	}
+	return "other"
+}
+
+// recordGarbage
+// A batch came in, hashes is how many got verified.
+func (s *Session) recordGarbage(bytes, hashes int, err error, now time.Time) {
+	s.gsmu.Lock()
+	defer s.gsmu.Unlock()
+	g := &s.garbageStats
+	if g.First.IsZero() {
+		g.First = now
+	}
+	g.Last = now
+	g.Batches++
+	g.Bytes += bytes
+	g.Hashes += hashes
+	if err != nil {
+		if g.Failures == nil {
+			g.Failures = make(map[string]int)
+		}
+		g.Failures[garbageErrorName(err)]++
+	}
+}
+
+// recordChallenge
+// A challenge was finished, returns the stats so far.
+func (s *Session) recordChallenge(took time.Duration) GarbageStats {
+	s.gsmu.Lock()
+	s.garbageStats.Challenges++
+	s.garbageStats.LastChallenge = took
+	s.garbageStats.ChallengeTime += took
+	s.gsmu.Unlock()
+	return s.GarbageStats()
+}
+
+// GarbageStats
+// Copy of the session's garbage counters.
+func (s *Session) GarbageStats() GarbageStats {
+	s.gsmu.Lock()
+	defer s.gsmu.Unlock()
+	g := s.garbageStats
+	g.Failures = maps.Clone(g.Failures)
+	return g
+}
+
+// GarbageStats
+// Every session's garbage counters.
+func (m *SessionManager) GarbageStats() map[uuid.UUID]GarbageStats {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	stats := make(map[uuid.UUID]GarbageStats, len(m.sessions))
+	for id, s := range m.sessions {
+		stats[id] = s.GarbageStats()
+	}
+	return stats
+}
+
+// garbageStatsJSON
+// GarbageStats with the worked out numbers, for HandleGarbageStats.
+type garbageStatsJSON struct {
+	GarbageStats
+	BatchesPerSecond float64       `json:"batchesPerSecond"`
+	AverageChallenge time.Duration `json:"averageChallenge"`
+}
+
+// HandleGarbageStats
+// JSON of every session's garbage stats, ?id= for one.
+func (s *WebTransportServer) HandleGarbageStats(w http.ResponseWriter, r *http.Request) {
+	stats := s.sessions.GarbageStats()
+	if id := r.URL.Query().Get("id"); id != "" {
+		one, ok := stats[uuid.FromStringOrNil(id)]
+		if !ok {
+			http.Error(w, "Not Found", http.StatusNotFound)
+			return
+		}
+		stats = map[uuid.UUID]GarbageStats{uuid.FromStringOrNil(id): one}
+	}
+	out := make(map[string]garbageStatsJSON, len(stats))
+	for id, g := range stats {
+		out[id.String()] = garbageStatsJSON{g, g.BatchesPerSecond(), g.AverageChallenge()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(out)
+	if err != nil {
+		log.Printf("Error writing garbage stats: %v\n", err)
+	}
+}
@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestGarbageStats(t *testing.T) {
+	s := &Session{}
+	now := time.Now()
+	s.recordGarbage(100, 10, nil, now)
+	s.recordGarbage(100, 0, fmt.Errorf("wrapped: %w", ErrGameGarbageMismatch), now.Add(time.Second))
+	s.recordGarbage(50, 0, ErrGameGarbageAmount, now.Add(2*time.Second))
+	s.recordGarbage(50, 0, fmt.Errorf("something else"), now.Add(4*time.Second))
+	s.recordChallenge(3 * time.Second)
+	g := s.recordChallenge(5 * time.Second)
+
+	if g.Batches != 4 || g.Bytes != 300 || g.Hashes != 10 {
+		t.Errorf("counts %+v", g)
+	}
+	if g.BatchesPerSecond() != 1 {
+		t.Errorf("batches per second %f", g.BatchesPerSecond())
+	}
+	if g.AverageChallenge() != 4*time.Second || g.LastChallenge != 5*time.Second {
+		t.Errorf("challenge times avg %s last %s", g.AverageChallenge(), g.LastChallenge)
+	}
+	want := map[string]int{
+		ErrGameGarbageMismatch.Error(): 1,
+		ErrGameGarbageAmount.Error():   1,
+		"other":                        1,
+	}
+	for k, v := range want {
+		if g.Failures[k] != v {
+			t.Errorf("failures %q = %d want %d", k, g.Failures[k], v)
+		}
+	}
+
+	// Copies don't share the map.
+	g.Failures["other"] = 100
+	if s.GarbageStats().Failures["other"] != 1 {
+		t.Error("stats map shared")
+	}
+}
@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"simpleWT/backend/cpnp"
+)
+
+// GarbageNonceSize bytes in a work mode nonce.
+const GarbageNonceSize = 8
+
+// maxGarbageDifficulty around 16 million hashes a nonce, a few seconds each.
+// Every bit doubles it, much more and a client never finishes a batch.
+const maxGarbageDifficulty = 24
+
+// garbageWorkCheck nonces tried between checks of the stop channel.
+const garbageWorkCheck = 1 << 12
+
+var (
+	ErrGameGarbageWork  = errors.New("garbage work not enough")
+	ErrGameGarbageNonce = errors.New("garbage nonce reused")
+)
+
+// LeadingZeroBits
+// Zero bits before the first one.
+func LeadingZeroBits(b []byte) int {
+	n := 0
+	for _, c := range b {
+		if c != 0 {
+			return n + bits.LeadingZeros8(c)
+		}
+		n += 8
+	}
+	return n
+}
+
+// GarbageWorkDigest
+// hash (base + nonce) for work mode.
+func GarbageWorkDigest(h cpnp.GarbageHash, base []byte, nonce uint64) []byte {
+	in := make([]byte, 0, len(base)+GarbageNonceSize)
+	in = append(in, base...)
+	in = binary.BigEndian.AppendUint64(in, nonce)
+	return garbageSum(h, in)
+}
+
+// SolveGarbageWork
+// The first nonce after the given one with enough leading zero bits.
+// Around 2^difficulty hashes, this is the expensive side.
+// Gives up with false once stop is closed, nil never stops.
+func SolveGarbageWork(h cpnp.GarbageHash, base []byte, difficulty int, after uint64, stop <-chan struct{}) (uint64, bool) {
+	difficulty = min(difficulty, maxGarbageDifficulty)
+	nonce := after + 1
+	for LeadingZeroBits(GarbageWorkDigest(h, base, nonce)) < difficulty {
+		nonce++
+		if nonce%garbageWorkCheck == 0 {
+			select {
+			case <-stop:
+				return nonce, false
+			default:
+			}
+		}
+	}
+	return nonce, true
+}
+
+// checkGarbageWork
+// One hash per nonce, the cheap side. Expects p.mu to be held.
+func (p *Player) checkGarbageWork(data []byte) error {
+	if len(data) != GarbageNonceSize {
+		return ErrGameGarbageHash
+	}
+	nonce := binary.BigEndian.Uint64(data)
+	// Going up means a nonce can't be sent twice without keeping them all.
+	if nonce <= p.garbageNonce {
+		return ErrGameGarbageNonce
+	}
+	if LeadingZeroBits(GarbageWorkDigest(p.GarbageHash, p.GarbageBase[:], nonce)) < p.GarbageDifficulty {
+		return ErrGameGarbageWork
+	}
+	p.garbageNonce = nonce
+	return nil
+}
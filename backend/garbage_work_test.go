@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"simpleWT/backend/cpnp"
+)
+
+func TestLeadingZeroBits(t *testing.T) {
+	tests := []struct {
+		in   []byte
+		want int
+	}{
+		{[]byte{0x80}, 0},
+		{[]byte{0x01}, 7},
+		{[]byte{0x00, 0x10}, 11},
+		{[]byte{0x00, 0x00}, 16},
+	}
+	for _, tt := range tests {
+		if got := LeadingZeroBits(tt.in); got != tt.want {
+			t.Errorf("LeadingZeroBits(%x) = %d want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGarbageWork(t *testing.T) {
+	p := &Player{
+		GarbageAmount:     2,
+		GarbageTotal:      2,
+		GarbageHash:       cpnp.GarbageHash_sha256,
+		GarbageMode:       cpnp.GarbageMode_work,
+		GarbageDifficulty: 8,
+	}
+	p.GarbageBase[0] = 1
+	nonce := func(n uint64) []byte {
+		return binary.BigEndian.AppendUint64(nil, n)
+	}
+
+	first, _ := SolveGarbageWork(p.GarbageHash, p.GarbageBase[:], p.GarbageDifficulty, 0, nil)
+	if err := p.checkGarbageWork(nonce(first)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.checkGarbageWork(nonce(first)); !errors.Is(err, ErrGameGarbageNonce) {
+		t.Errorf("reused nonce: %v", err)
+	}
+	// Next one up that isn't a solution.
+	bad := first + 1
+	for LeadingZeroBits(GarbageWorkDigest(p.GarbageHash, p.GarbageBase[:], bad)) >= p.GarbageDifficulty {
+		bad++
+	}
+	if err := p.checkGarbageWork(nonce(bad)); !errors.Is(err, ErrGameGarbageWork) {
+		t.Errorf("unsolved nonce: %v", err)
+	}
+	if err := p.checkGarbageWork([]byte{1, 2}); !errors.Is(err, ErrGameGarbageHash) {
+		t.Errorf("short nonce: %v", err)
+	}
+
+	// Through HandleGarbage like a client would.
+	msg, err := NewMessage(NewPacketWriter(), cpnp.NewRootGameClientGarbage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := msg.NewHash(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := first
+	for i := range list.Len() {
+		n, _ = SolveGarbageWork(p.GarbageHash, p.GarbageBase[:], p.GarbageDifficulty, n, nil)
+		_ = list.At(i).SetData(nonce(n))
+	}
+	if done, err := p.HandleGarbage(list); err != nil || !done {
+		t.Errorf("work message: %v %v", done, err)
+	}
+}
+
+func TestGarbageWorkLimits(t *testing.T) {
+	s := new(Session)
+	s.SetGarbageDifficulty(64)
+	if d := s.GarbageDifficulty(); d != maxGarbageDifficulty {
+		t.Errorf("difficulty %d, want capped at %d", d, maxGarbageDifficulty)
+	}
+	policy := GarbagePolicy{Amount: Fixed(1), Rate: Fixed(1), Duration: Fixed(1), Difficulty: Fixed(64)}
+	if c := policy.Pick(new(Session), 1); c.Difficulty != maxGarbageDifficulty {
+		t.Errorf("picked difficulty %d, want capped at %d", c.Difficulty, maxGarbageDifficulty)
+	}
+
+	stop := make(chan struct{})
+	close(stop)
+	if _, ok := SolveGarbageWork(cpnp.GarbageHash_sha1, []byte{1}, 64, 0, stop); ok {
+		t.Error("solved 64 bits")
+	}
+
+	for _, tt := range []struct {
+		in   string
+		want cpnp.GarbageMode
+		err  error
+	}{
+		{"", cpnp.GarbageMode_recompute, nil},
+		{" Work ", cpnp.GarbageMode_work, nil},
+		{"wrok", 0, ErrGarbageMode},
+	} {
+		got, err := ParseGarbageMode(tt.in)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("ParseGarbageMode(%q) = %v, %v want %v, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestClientGarbageChallenge(t *testing.T) {
+	writer := NewPacketWriter()
+	request := func(challenge uint32, amount uint32, base []byte) []byte {
+		msg, err := NewMessage(writer, cpnp.NewRootGameServerGarbage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.SetAmount(amount)
+		msg.SetPer(1)
+		msg.SetHash(cpnp.GarbageHash_sha256)
+		msg.SetChallenge(challenge)
+		msg.SetBatches(3)
+		msg.SetWindow(2)
+		_ = msg.SetBase(base)
+		return testPayload(t, writer, msg.Message(), OpCodeSGarbage)
+	}
+
+	c := newClient(ClientConnection{Name: "bot"})
+	defer c.Close()
+	payload := request(1, 2, []byte{1, 2, 3})
+	c.HandleGarbageRequest(payload)
+	ch := c.garbage.Load()
+	if ch == nil || ch.id != 1 || ch.amount != 2 || ch.batches != 3 || ch.window != 2 {
+		t.Fatalf("challenge %+v", ch)
+	}
+	clear(payload)
+	if ch.base[0] != 1 || ch.base[2] != 3 {
+		t.Errorf("base shares the packet buffer: %v", ch.base)
+	}
+
+	// A new one doesn't change the one being worked on, counters included.
+	ch.sent.Store(2)
+	ch.acked.Store(1)
+	c.HandleGarbageRequest(request(2, 5, []byte{4, 5, 6}))
+	if ch.sent.Load() != 2 || ch.acked.Load() != 1 {
+		t.Errorf("old counters reset to %d sent %d acked", ch.sent.Load(), ch.acked.Load())
+	}
+	if data := c.garbageData(ch); len(data) != 2 || ch.id != 1 {
+		t.Errorf("old challenge changed, %d hashes for %d", len(data), ch.id)
+	}
+	if next := c.garbage.Load(); next == ch || next.id != 2 || next.amount != 5 {
+		t.Errorf("new challenge %+v", next)
+	} else if next.sent.Load() != 0 || next.acked.Load() != 0 {
+		t.Errorf("new challenge starts at %d sent", next.sent.Load())
+	}
+}
@@ -0,0 +1,238 @@
+package backend
+
+import (
+	"cmp"
+	"errors"
+	"log"
+	"slices"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrItemNoRoom = errors.New("no free cell for item")
+)
+
+// ItemSpawn
+// Spawn rule for a kind of item.
+type ItemSpawn struct {
+	Kind string
+	// Count how many are on the map at once.
+	Count int
+	// Respawn how long after a pickup a new one shows up, 0 for never.
+	Respawn time.Duration
+}
+
+// Item
+// Something lying on a cell waiting to be picked up.
+type Item struct {
+	ID   uint32
+	Kind string
+	X, Y int
+
+	spawn ItemSpawn
+}
+
+type itemRespawn struct {
+	at    time.Time
+	spawn ItemSpawn
+}
+
+// Inventory
+// Item kind to how many a player has.
+type Inventory map[string]int
+
+// spawnItems
+// Puts the configured items on the map.
+func (w *GameWorld) spawnItems() {
+	for _, spawn := range w.config.Items {
+		for range spawn.Count {
+			_, err := w.SpawnItem(spawn)
+			if err != nil {
+				log.Printf("Error spawning %s in %s: %v\n", spawn.Kind, w.Name, err)
+				return
+			}
+		}
+	}
+}
+
+// SpawnItem
+// Puts an item on a random cell without a wall or another item.
+func (w *GameWorld) SpawnItem(spawn ItemSpawn) (*Item, error) {
+	w.imu.Lock()
+	var c Cell
+	found := false
+	for range spawnAttempts {
+		c = w.Map.RandomCell()
+		if _, taken := w.items[c]; !taken && !w.Map.Wall(c.X, c.Y) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		w.imu.Unlock()
+		return nil, ErrItemNoRoom
+	}
+	w.nextItem++
+	item := &Item{ID: w.nextItem, Kind: spawn.Kind, X: c.X, Y: c.Y, spawn: spawn}
+	w.items[c] = item
+	w.imu.Unlock()
+	w.record(Event{Kind: EventItemSpawn, Item: item.ID, Text: item.Kind, X: item.X, Y: item.Y})
+
+	w.itemSend(item, true, "")
+	return item, nil
+}
+
+// Items
+// Everything on the map, by ID.
+func (w *GameWorld) Items() []Item {
+	w.imu.Lock()
+	items := make([]Item, 0, len(w.items))
+	for _, item := range w.items {
+		items = append(items, *item)
+	}
+	w.imu.Unlock()
+	slices.SortFunc(items, func(a, b Item) int { return cmp.Compare(a.ID, b.ID) })
+	return items
+}
+
+// takeItem
+// Removes the item on a cell, only one caller ever gets it.
+func (w *GameWorld) takeItem(x, y int, now time.Time) (*Item, bool) {
+	w.imu.Lock()
+	defer w.imu.Unlock()
+	c := Cell{x, y}
+	item, ok := w.items[c]
+	if !ok {
+		return nil, false
+	}
+	delete(w.items, c)
+	if item.spawn.Respawn > 0 {
+		w.respawns = append(w.respawns, itemRespawn{now.Add(item.spawn.Respawn), item.spawn})
+	}
+	return item, true
+}
+
+// pickup
+// Collects whatever is on the cell a player just moved onto.
+func (w *GameWorld) pickup(s *Session, p *Player, x, y int, now time.Time) {
+	item, ok := w.takeItem(x, y, now)
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	if p.Inventory == nil {
+		p.Inventory = make(Inventory)
+	}
+	p.Inventory[item.Kind]++
+	p.mu.Unlock()
+	w.record(Event{Kind: EventItemPickup, ID: s.ID.String(), Name: p.Name, Item: item.ID, Text: item.Kind, X: x, Y: y})
+
+	w.itemSend(item, false, s.ID.String())
+	w.sendInventory(s)
+}
+
+// tickItems
+// Brings back items whose respawn timer ran out.
+func (w *GameWorld) tickItems(now time.Time) {
+	w.imu.Lock()
+	var due []ItemSpawn
+	w.respawns = slices.DeleteFunc(w.respawns, func(r itemRespawn) bool {
+		if now.Before(r.at) {
+			return false
+		}
+		due = append(due, r.spawn)
+		return true
+	})
+	w.imu.Unlock()
+
+	for _, spawn := range due {
+		_, err := w.SpawnItem(spawn)
+		if err != nil {
+			log.Printf("Error respawning %s in %s: %v\n", spawn.Kind, w.Name, err)
+		}
+	}
+}
+
+func (w *GameWorld) itemSend(item *Item, spawned bool, by string) {
+	w.writer.mu.Lock()
+	defer w.writer.mu.Unlock()
+	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastItem)
+	if err != nil {
+		log.Printf("Error creating item packet: %v", err)
+		return
+	}
+	it, err := msg.NewItem()
+	if err != nil {
+		log.Printf("Error creating item packet: %v", err)
+		return
+	}
+	it.SetId(item.ID)
+	it.SetX(int32(item.X))
+	it.SetY(int32(item.Y))
+	_ = it.SetKind(item.Kind)
+	msg.SetSpawned(spawned)
+	_ = msg.SetBy(by)
+	w.Broadcast(msg.Message(), OpCodeBItem)
+}
+
+func (w *GameWorld) sendItems(s *Session) {
+	items := w.Items()
+	err := QueueMessage(s, OpCodeSItems, cpnp.NewRootGameServerItems, func(msg cpnp.GameServerItems) error {
+		list, err := msg.NewItems(int32(len(items)))
+		if err != nil {
+			return err
+		}
+		for i, item := range items {
+			it := list.At(i)
+			it.SetId(item.ID)
+			it.SetX(int32(item.X))
+			it.SetY(int32(item.Y))
+			err = it.SetKind(item.Kind)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error sending items packet: %v\n", err)
+	}
+}
+
+func (w *GameWorld) sendInventory(s *Session) {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return
+	}
+	p.mu.Lock()
+	kinds := make([]string, 0, len(p.Inventory))
+	counts := make(map[string]int, len(p.Inventory))
+	for kind, n := range p.Inventory {
+		kinds = append(kinds, kind)
+		counts[kind] = n
+	}
+	p.mu.Unlock()
+	slices.Sort(kinds)
+
+	err := QueueMessage(s, OpCodeSInventory, cpnp.NewRootGameServerInventory, func(msg cpnp.GameServerInventory) error {
+		list, err := msg.NewItems(int32(len(kinds)))
+		if err != nil {
+			return err
+		}
+		for i, kind := range kinds {
+			list.At(i).SetCount(uint32(counts[kind]))
+			err = list.At(i).SetKind(kind)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error sending inventory packet: %v\n", err)
+	}
+}
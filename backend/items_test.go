@@ -0,0 +1,56 @@
+package backend
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestItemPickupRace(t *testing.T) {
+	config := DefaultGameConfig()
+	config.MapWidth, config.MapHeight = 10, 10
+	config.Items = nil
+	w := NewGameWorld("test", NewDatabaseManager(), config)
+
+	item, err := w.SpawnItem(ItemSpawn{Kind: "coin", Count: 1, Respawn: time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	players := make([]*Player, 8)
+	sessions := make([]*Session, len(players))
+	for i := range players {
+		sessions[i] = new(Session)
+		players[i] = &Player{Name: "p"}
+		w.Players[sessions[i]] = players[i]
+	}
+
+	var wg sync.WaitGroup
+	now := time.Now()
+	for i := range players {
+		wg.Go(func() {
+			w.pickup(sessions[i], players[i], item.X, item.Y, now)
+		})
+	}
+	wg.Wait()
+
+	total := 0
+	for _, p := range players {
+		total += p.Inventory["coin"]
+	}
+	if total != 1 {
+		t.Fatalf("item picked up %d times", total)
+	}
+	if len(w.Items()) != 0 {
+		t.Fatal("item still on the map")
+	}
+
+	w.tickItems(now.Add(time.Second / 2))
+	if len(w.Items()) != 0 {
+		t.Error("respawned early")
+	}
+	w.tickItems(now.Add(time.Second))
+	if len(w.Items()) != 1 {
+		t.Error("didn't respawn")
+	}
+}
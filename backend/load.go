@@ -0,0 +1,244 @@
+package backend
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// garbageDecisions how many decisions the controller remembers.
+const garbageDecisions = 100
+
+// LoadSignals
+// How busy the server looked at a sample.
+type LoadSignals struct {
+	// Queued packets waiting in session queues.
+	Queued int `json:"queued"`
+	// Latency average handler time since the last sample.
+	Latency    time.Duration `json:"latency"`
+	Goroutines int           `json:"goroutines"`
+	// TickTime slowest world tick.
+	TickTime time.Duration `json:"tickTime"`
+}
+
+// AdaptiveGarbageConfig
+// When to ask for less garbage.
+// Signal limits past which the server counts as loaded, 0 ignores that signal.
+type AdaptiveGarbageConfig struct {
+	// Every how often to check, 0 turns it off.
+	Every time.Duration
+	// MinScale and MaxScale limit the scale, 1 is the policy as is.
+	// MinScale is at least minGarbageScale, steps multiply so 0 would never come back.
+	MinScale float64
+	MaxScale float64
+	// Step how much the scale changes per check, 0.25 is a quarter.
+	Step float64
+
+	Queue      int
+	Latency    time.Duration
+	Goroutines int
+	TickTime   time.Duration
+}
+
+// minGarbageScale lowest MinScale, a hundredth of the policy.
+const minGarbageScale = 0.01
+
+// GarbageDecision
+// One check of the controller.
+type GarbageDecision struct {
+	Time    time.Time   `json:"time"`
+	Signals LoadSignals `json:"signals"`
+	// Over signals that were past their limit.
+	Over  []string `json:"over,omitempty"`
+	Scale float64  `json:"scale"`
+}
+
+// GarbageController
+// Scales garbage amount and rate of new challenges with server load.
+// Down a step when any signal is over its limit,
+// back up a step when all of them are under half.
+type GarbageController struct {
+	config AdaptiveGarbageConfig
+
+	// scale float64 bits
+	scale atomic.Uint64
+
+	mu        sync.Mutex
+	decisions []GarbageDecision
+}
+
+func NewGarbageController(config AdaptiveGarbageConfig) *GarbageController {
+	if config.MaxScale <= 0 {
+		config.MaxScale = 1
+	}
+	config.MinScale = min(max(config.MinScale, minGarbageScale), config.MaxScale)
+	c := &GarbageController{config: config}
+	c.scale.Store(math.Float64bits(min(1, config.MaxScale)))
+	return c
+}
+
+// Scale
+// What new challenges get multiplied by. Nil controller is 1.
+func (c *GarbageController) Scale() float64 {
+	if c == nil {
+		return 1
+	}
+	return math.Float64frombits(c.scale.Load())
+}
+
+// Decisions
+// The most recent checks, oldest first.
+func (c *GarbageController) Decisions() []GarbageDecision {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]GarbageDecision, len(c.decisions))
+	copy(out, c.decisions)
+	return out
+}
+
+// Decide
+// Moves the scale for a sample.
+func (c *GarbageController) Decide(sig LoadSignals, now time.Time) GarbageDecision {
+	conf := c.config
+	var over []string
+	calm := true
+	check := func(name string, value, limit float64) {
+		if limit <= 0 {
+			return
+		}
+		if value > limit {
+			over = append(over, name)
+		}
+		if value > limit/2 {
+			calm = false
+		}
+	}
+	check("queued", float64(sig.Queued), float64(conf.Queue))
+	check("latency", float64(sig.Latency), float64(conf.Latency))
+	check("goroutines", float64(sig.Goroutines), float64(conf.Goroutines))
+	check("tickTime", float64(sig.TickTime), float64(conf.TickTime))
+
+	old := c.Scale()
+	scale := old
+	if len(over) > 0 {
+		scale = max(scale*(1-conf.Step), conf.MinScale)
+	} else if calm {
+		scale = min(scale*(1+conf.Step), conf.MaxScale)
+	}
+	c.scale.Store(math.Float64bits(scale))
+	if scale != old {
+		log.Printf("Garbage scale %.2f -> %.2f, over: %v, %+v\n", old, scale, over, sig)
+	}
+
+	d := GarbageDecision{Time: now, Signals: sig, Over: over, Scale: scale}
+	c.mu.Lock()
+	c.decisions = append(c.decisions, d)
+	if len(c.decisions) > garbageDecisions {
+		c.decisions = c.decisions[len(c.decisions)-garbageDecisions:]
+	}
+	c.mu.Unlock()
+	return d
+}
+
+// Run
+// Samples and decides every config.Every until closing.
+func (c *GarbageController) Run(sample func() LoadSignals, closing <-chan struct{}) {
+	if c == nil || c.config.Every <= 0 {
+		return
+	}
+	ticker := time.NewTicker(c.config.Every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closing:
+			return
+		case now := <-ticker.C:
+			c.Decide(sample(), now)
+		}
+	}
+}
+
+// scaleRange
+// A range multiplied by scale, never below 1.
+func scaleRange(r Range, scale float64) Range {
+	if scale == 1 {
+		return r
+	}
+	return Range{
+		Min: max(int(math.Round(float64(r.Min)*scale)), 1),
+		Max: max(int(math.Round(float64(r.Max)*scale)), 1),
+	}
+}
+
+// loadSampler
+// Turns running totals into signals, handler time is since the last sample.
+type loadSampler struct {
+	sessions *SessionManager
+	worlds   *WorldManager
+
+	handled     int64
+	handlerTime time.Duration
+}
+
+func (l *loadSampler) Sample() LoadSignals {
+	queued, handled, handlerTime := l.sessions.load()
+	sig := LoadSignals{
+		Queued:     queued,
+		Goroutines: runtime.NumGoroutine(),
+		TickTime:   l.worlds.tickTime(),
+	}
+	// Goes backwards when sessions get pruned, skip those.
+	if n := handled - l.handled; n > 0 && handlerTime >= l.handlerTime {
+		sig.Latency = (handlerTime - l.handlerTime) / time.Duration(n)
+	}
+	l.handled, l.handlerTime = handled, handlerTime
+	return sig
+}
+
+// load
+// Packets queued and handler totals across sessions.
+func (m *SessionManager) load() (queued int, handled int64, handlerTime time.Duration) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range m.sessions {
+		queued += len(s.incoming)
+		handled += s.handled.Load()
+		handlerTime += time.Duration(s.handlerTime.Load())
+	}
+	return queued, handled, handlerTime
+}
+
+// tickTime
+// Slowest last tick of any world.
+func (m *WorldManager) tickTime() time.Duration {
+	m.wmu.RLock()
+	defer m.wmu.RUnlock()
+	var slowest time.Duration
+	for _, w := range m.worlds {
+		slowest = max(slowest, time.Duration(w.tickTime.Load()))
+	}
+	return slowest
+}
+
+// HandleLoadStats
+// JSON of the garbage scale and the controller's recent decisions.
+func (s *WebTransportServer) HandleLoadStats(w http.ResponseWriter, r *http.Request) {
+	c := s.worlds.Garbage
+	out := struct {
+		Scale     float64           `json:"scale"`
+		Decisions []GarbageDecision `json:"decisions"`
+	}{c.Scale(), c.Decisions()}
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(out)
+	if err != nil {
+		log.Printf("Error writing load stats: %v\n", err)
+	}
+}
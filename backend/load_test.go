@@ -0,0 +1,84 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGarbageController(t *testing.T) {
+	c := NewGarbageController(AdaptiveGarbageConfig{
+		MinScale: 0.5,
+		MaxScale: 1,
+		Step:     0.25,
+		Queue:    100,
+		TickTime: 20 * time.Millisecond,
+	})
+	if c.Scale() != 1 {
+		t.Fatalf("starting scale %v, want 1", c.Scale())
+	}
+	now := time.Now()
+
+	d := c.Decide(LoadSignals{Queued: 200}, now)
+	if d.Scale != 0.75 || len(d.Over) != 1 || d.Over[0] != "queued" {
+		t.Errorf("over queue: %+v", d)
+	}
+	d = c.Decide(LoadSignals{TickTime: time.Second}, now)
+	if d.Scale != 0.5625 {
+		t.Errorf("second step down %v, want 0.5625", d.Scale)
+	}
+	d = c.Decide(LoadSignals{Queued: 1000}, now)
+	if d.Scale != 0.5 {
+		t.Errorf("below MinScale %v", d.Scale)
+	}
+
+	// Between half and the limit holds.
+	d = c.Decide(LoadSignals{Queued: 75}, now)
+	if d.Scale != 0.5 {
+		t.Errorf("held scale %v, want 0.5", d.Scale)
+	}
+	for range 5 {
+		d = c.Decide(LoadSignals{Queued: 10}, now)
+	}
+	if d.Scale != 1 {
+		t.Errorf("calm scale %v, want 1", d.Scale)
+	}
+	if n := len(c.Decisions()); n != 9 {
+		t.Errorf("%d decisions, want 9", n)
+	}
+
+	var nilc *GarbageController
+	if nilc.Scale() != 1 {
+		t.Error("nil controller should scale by 1")
+	}
+}
+
+func TestGarbageControllerBounds(t *testing.T) {
+	c := NewGarbageController(AdaptiveGarbageConfig{MaxScale: 2, Step: 0.5, Queue: 100})
+	now := time.Now()
+	var d GarbageDecision
+	for range 20 {
+		d = c.Decide(LoadSignals{Queued: 1000}, now)
+	}
+	if d.Scale != minGarbageScale {
+		t.Fatalf("MinScale 0 went to %v, want %v", d.Scale, minGarbageScale)
+	}
+	for range 20 {
+		d = c.Decide(LoadSignals{}, now)
+	}
+	if d.Scale != 2 {
+		t.Errorf("calm scale %v, want MaxScale 2", d.Scale)
+	}
+}
+
+func TestScaleRange(t *testing.T) {
+	r := Range{Min: 10, Max: 20}
+	if got := scaleRange(r, 1); got != r {
+		t.Errorf("scale 1 %v", got)
+	}
+	if got := scaleRange(r, 0.5); got != (Range{5, 10}) {
+		t.Errorf("scale 0.5 %v", got)
+	}
+	if got := scaleRange(r, 0.01); got != (Range{1, 1}) {
+		t.Errorf("scale 0.01 %v", got)
+	}
+}
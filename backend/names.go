@@ -0,0 +1,63 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	NameMinLength = 3
+	NameMaxLength = 32
+)
+
+var (
+	ErrNameLength   = fmt.Errorf("name must be %d to %d characters", NameMinLength, NameMaxLength)
+	ErrNameChars    = errors.New("name has invalid characters")
+	ErrNameReserved = errors.New("name is reserved")
+	ErrNameTaken    = errors.New("name is taken")
+)
+
+// reservedNames
+// Can't be picked by anyone, checked ignoring case.
+var reservedNames = []string{
+	"admin", "administrator", "moderator", "mod",
+	"server", "system", "everyone", "npc",
+}
+
+// CleanName
+// Trims and collapses spaces then checks the name is allowed.
+// Letters, numbers, spaces and - _ . ' only, with at least one letter or number.
+func CleanName(name string) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	n := utf8.RuneCountInString(name)
+	if n < NameMinLength || n > NameMaxLength {
+		return "", ErrNameLength
+	}
+	alnum := false
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			alnum = true
+		case r == ' ', r == '-', r == '_', r == '.', r == '\'':
+		default:
+			return "", fmt.Errorf("%w: %q", ErrNameChars, r)
+		}
+	}
+	if !alnum {
+		return "", ErrNameChars
+	}
+	if slices.Contains(reservedNames, nameKey(name)) {
+		return "", ErrNameReserved
+	}
+	return name, nil
+}
+
+// nameKey
+// What names are unique by, case doesn't count.
+func nameKey(name string) string {
+	return strings.ToLower(name)
+}
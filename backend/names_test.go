@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCleanName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		err  error
+	}{
+		{"  Dr.  Jane   Doe ", "Dr. Jane Doe", nil},
+		{"O'Neil-2", "O'Neil-2", nil},
+		{"ab", "", ErrNameLength},
+		{"this name is much much too long for anyone", "", ErrNameLength},
+		{"bad<script>", "", ErrNameChars},
+		{"---", "", ErrNameChars},
+		{"ADMIN", "", ErrNameReserved},
+	}
+	for _, tt := range tests {
+		got, err := CleanName(tt.name)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("CleanName(%q) = %q, %v want %q, %v", tt.name, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestRename(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Rename = RateLimit{Rate: 0.001, Burst: 2}
+	})
+	db, worlds, w := g.db, g.worlds, g.w
+
+	taken, err := db.GetUser("Taken Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := db.GetUser("taken  NAME"); again != taken {
+		t.Error("names should match ignoring case and spaces")
+	}
+
+	s := g.join(t, "")
+	if err := worlds.Rename(s, "TAKEN name"); !errors.Is(err, ErrNameTaken) {
+		t.Fatalf("expected taken, got %v", err)
+	}
+	if err := worlds.Rename(s, "x"); !errors.Is(err, ErrNameLength) {
+		t.Fatalf("expected length, got %v", err)
+	}
+	if err := worlds.Rename(s, "New Name"); err != nil {
+		t.Fatal(err)
+	}
+	if w.Players[s].Name != "New Name" {
+		t.Error("player not renamed")
+	}
+	if name, _ := db.GetUserByID(s.ID); name != "New Name" {
+		t.Errorf("user not renamed, got %q", name)
+	}
+	if _, _, err := db.RenameUser(taken, "new name"); !errors.Is(err, ErrNameTaken) {
+		t.Errorf("expected taken, got %v", err)
+	}
+	// The taken name used up one, that was two.
+	if err := worlds.Rename(s, "Newer Name"); !errors.Is(err, ErrRenameTooSoon) {
+		t.Errorf("expected rate limit, got %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package backend
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// NPC
+// An entity the world moves itself, there is no session behind it.
+type NPC struct {
+	ID uuid.UUID
+	// Player for the name and position, the map only knows players.
+	Player    *Player
+	Behaviour Behaviour
+}
+
+// Behaviour
+// Decides where an NPC goes next, called when the NPC is allowed to move.
+// Return 0, 0 to stay put.
+type Behaviour interface {
+	Step(w *GameWorld, n *NPC) (dx, dy int)
+}
+
+// Wander
+// Random steps, Pause is the chance of standing still.
+type Wander struct {
+	Pause float64
+}
+
+func (b *Wander) Step(_ *GameWorld, _ *NPC) (int, int) {
+	if rand.Float64() < b.Pause {
+		return 0, 0
+	}
+	return rand.IntN(3) - 1, rand.IntN(3) - 1
+}
+
+// Patrol
+// Walks the path in order, looping back to the start.
+type Patrol struct {
+	Path []Cell
+	next int
+}
+
+func (b *Patrol) Step(w *GameWorld, n *NPC) (int, int) {
+	if len(b.Path) == 0 {
+		return 0, 0
+	}
+	x, y := n.position()
+	if b.Path[b.next] == (Cell{x, y}) {
+		b.next = (b.next + 1) % len(b.Path)
+	}
+	to := b.Path[b.next]
+	return w.Map.Toward(x, y, to.X, to.Y)
+}
+
+// Follow
+// Walks toward the nearest player within Radius, 0 is any distance.
+type Follow struct {
+	Radius int
+}
+
+func (b *Follow) Step(w *GameWorld, n *NPC) (int, int) {
+	x, y := n.position()
+	c, d, ok := w.nearestPlayer(x, y, b.Radius)
+	if !ok || d <= 1 {
+		return 0, 0
+	}
+	return w.Map.Toward(x, y, c.X, c.Y)
+}
+
+// Flee
+// Walks away from the nearest player within Radius.
+type Flee struct {
+	Radius int
+}
+
+func (b *Flee) Step(w *GameWorld, n *NPC) (int, int) {
+	x, y := n.position()
+	c, _, ok := w.nearestPlayer(x, y, b.Radius)
+	if !ok {
+		return 0, 0
+	}
+	dx, dy := w.Map.Toward(x, y, c.X, c.Y)
+	if dx == 0 && dy == 0 {
+		// Standing on them without collision, pick anything.
+		return rand.IntN(3) - 1, rand.IntN(3) - 1
+	}
+	return -dx, -dy
+}
+
+// RandomBehaviour
+// One of the built in behaviours, used for config spawned NPCs.
+func RandomBehaviour(m *GameMap) Behaviour {
+	switch rand.IntN(4) {
+	case 0:
+		return &Wander{Pause: 0.25}
+	case 1:
+		path := make([]Cell, 4)
+		for i := range path {
+			path[i] = Cell{rand.IntN(m.Width), rand.IntN(m.Height)}
+		}
+		return &Patrol{Path: path}
+	case 2:
+		return &Follow{Radius: 10}
+	default:
+		return &Flee{Radius: 5}
+	}
+}
+
+func (n *NPC) position() (int, int) {
+	n.Player.mu.Lock()
+	defer n.Player.mu.Unlock()
+	return n.Player.X, n.Player.Y
+}
+
+// AddNPC
+// Spawns an NPC and tells everyone about it.
+func (w *GameWorld) AddNPC(name string, b Behaviour) (*NPC, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	n := &NPC{
+		ID: id,
+		Player: &Player{
+			Name:       name,
+			moveBudget: newTokenBucket(w.config.NPC.Speed),
+		},
+		Behaviour: b,
+	}
+	err = w.Map.Spawn(n.Player)
+	if err != nil {
+		return nil, err
+	}
+
+	w.nmu.Lock()
+	w.NPCs[id] = n
+	w.nmu.Unlock()
+	x, y := n.position()
+	w.record(Event{Kind: EventConnect, ID: id.String(), Name: name, X: x, Y: y})
+	w.connectedSend(nil, id.String(), name, x, y, n.Player.Presence, true)
+	return n, nil
+}
+
+// RemoveNPC
+// Takes an NPC out of the world, false if it wasn't there.
+func (w *GameWorld) RemoveNPC(id uuid.UUID) bool {
+	w.nmu.Lock()
+	n, ok := w.NPCs[id]
+	delete(w.NPCs, id)
+	w.nmu.Unlock()
+	if !ok {
+		return false
+	}
+	w.Map.Remove(n.Player)
+	w.record(Event{Kind: EventDisconnect, ID: id.String(), Name: n.Player.Name})
+	w.connectedSend(nil, id.String(), n.Player.Name, 0, 0, n.Player.Presence, false)
+	return true
+}
+
+// tickNPCs
+// Moves every NPC that has the budget for it.
+func (w *GameWorld) tickNPCs(now time.Time) {
+	w.nmu.RLock()
+	npcs := make([]*NPC, 0, len(w.NPCs))
+	for _, n := range w.NPCs {
+		npcs = append(npcs, n)
+	}
+	w.nmu.RUnlock()
+
+	for _, n := range npcs {
+		n.Player.mu.Lock()
+		allowed := n.Player.moveBudget.Allow(now)
+		n.Player.mu.Unlock()
+		if !allowed {
+			continue
+		}
+
+		dx, dy := n.Behaviour.Step(w, n)
+		if dx == 0 && dy == 0 {
+			continue
+		}
+		x, y, moved := w.Map.Move(n.Player, sign(dx), sign(dy))
+		if !moved {
+			continue
+		}
+		w.moveSend(n.ID.String(), n.Player.Name, x, y)
+	}
+}
+
+// nearestPlayer
+// Closest player to a cell within radius, 0 is any distance.
+func (w *GameWorld) nearestPlayer(x, y, radius int) (Cell, int, bool) {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	best := Cell{}
+	bestD := -1
+	for _, p := range w.Players {
+		p.mu.Lock()
+		c := Cell{p.X, p.Y}
+		p.mu.Unlock()
+		d := w.Map.Distance(x, y, c.X, c.Y)
+		if radius > 0 && d > radius {
+			continue
+		}
+		if bestD < 0 || d < bestD {
+			best, bestD = c, d
+		}
+	}
+	if bestD < 0 {
+		return Cell{}, 0, false
+	}
+	return best, bestD, true
+}
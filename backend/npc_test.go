@@ -0,0 +1,61 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNPCBehaviours(t *testing.T) {
+	config := DefaultGameConfig()
+	config.MapWidth, config.MapHeight = 20, 20
+	config.NPC.Speed = RateLimit{}
+	w := NewGameWorld("test", NewDatabaseManager(), config)
+
+	p := &Player{Name: "target"}
+	if err := w.Map.Place(p, 5, 5); err != nil {
+		t.Fatal(err)
+	}
+	w.Players[new(Session)] = p
+
+	follow, err := w.AddNPC("follow", &Follow{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	flee, err := w.AddNPC("flee", &Flee{Radius: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	patrol, err := w.AddNPC("patrol", &Patrol{Path: []Cell{{10, 10}, {12, 10}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Map.Place(follow.Player, 15, 5)
+	_ = w.Map.Place(flee.Player, 7, 5)
+	_ = w.Map.Place(patrol.Player, 10, 10)
+
+	for range 10 {
+		w.tickNPCs(time.Now())
+	}
+
+	x, y := follow.position()
+	if d := w.Map.Distance(x, y, 5, 5); d != 1 {
+		t.Errorf("follower %d away at %d,%d", d, x, y)
+	}
+	x, y = flee.position()
+	if d := w.Map.Distance(x, y, 5, 5); d <= 5 {
+		t.Errorf("fleer only %d away at %d,%d", d, x, y)
+	}
+	// Patrol goes 10 -> 12 -> 10, ten steps is back and forth twice and a half.
+	x, y = patrol.position()
+	if y != 10 || x < 10 || x > 12 {
+		t.Errorf("patrol left its path %d,%d", x, y)
+	}
+
+	x, y = follow.position()
+	if !w.RemoveNPC(follow.ID) || w.RemoveNPC(follow.ID) {
+		t.Error("remove")
+	}
+	if w.Map.At(x, y) == follow.Player {
+		t.Error("removed NPC still on map")
+	}
+}
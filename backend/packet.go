@@ -26,18 +26,35 @@ const (
 	OpCodeBConnect
 	OpCodeBPlayerMoved
 	OpCodeBChat
+	OpCodeBPresence
+	OpCodeBItem
+	OpCodeBRename
 
 	// Game Server Opcodes
 	_
 	OpCodeSGarbage
 	OpCodeSGarbageAck
 	OpCodeSPlayers
+	OpCodeSMoveCorrection
+	OpCodeSMoveAck
+	OpCodeSNotice
+	OpCodeSWorld
+	OpCodeSChatHistory
+	OpCodeSItems
+	OpCodeSInventory
+	OpCodeSPathStep
 
 	// Game Client Opcodes
 	_
 	OpCodeCChat
 	OpCodeCMoved
 	OpCodeCGarbage
+	OpCodeCChangeWorld
+	OpCodeCChatHistory
+	OpCodeCMoveTo
+	OpCodeCSpectate
+	OpCodeCRename
+	OpCodeCPlayers
 )
 
 type CapnpMessage interface {
@@ -88,10 +105,9 @@ const PacketBufferSize = 8
 // PacketWriter
 // A way to create capnp messages
 type PacketWriter struct {
-	mu    sync.Mutex
-	arena capnp.Arena
-	msg   *capnp.Message
-	buf   []byte
+	mu  sync.Mutex
+	msg *capnp.Message
+	buf []byte
 }
 
 // PacketWriteSender
@@ -120,9 +136,8 @@ type PacketReader struct {
 func NewPacketWriter() *PacketWriter {
 	msg, _, _ := capnp.NewMessage(capnp.SingleSegment(nil))
 	return &PacketWriter{
-		arena: msg.Arena,
-		msg:   msg,
-		buf:   make([]byte, PacketBufferSize),
+		msg: msg,
+		buf: make([]byte, PacketBufferSize),
 	}
 }
 
@@ -137,7 +152,8 @@ func NewPacketReader() *PacketReader {
 // NewMessage
 // Preps a message to be sent using a PacketWriter
 func NewMessage[T CapnpMessage](w *PacketWriter, ctor func(*capnp.Segment) (T, error)) (T, error) {
-	seg, err := w.msg.Reset(w.arena)
+	// Reset puts the old arena back in capnp's pool, reusing it would share it with whoever takes it next.
+	seg, err := w.msg.Reset(capnp.SingleSegment(nil))
 	if err != nil {
 		var zero T
 		return zero, fmt.Errorf("new message: %w", err)
@@ -0,0 +1,125 @@
+package backend
+
+import (
+	"container/heap"
+	"errors"
+)
+
+var (
+	ErrMapNoPath = errors.New("no path")
+)
+
+type pathNode struct {
+	c    Cell
+	g, f int
+	// Heap index
+	index int
+}
+
+type pathQueue []*pathNode
+
+func (q pathQueue) Len() int { return len(q) }
+func (q pathQueue) Less(i, j int) bool {
+	if q[i].f == q[j].f {
+		// Closer to the goal first, fewer nodes to look at.
+		return q[i].g > q[j].g
+	}
+	return q[i].f < q[j].f
+}
+func (q pathQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *pathQueue) Push(x any) {
+	n := x.(*pathNode)
+	n.index = len(*q)
+	*q = append(*q, n)
+}
+func (q *pathQueue) Pop() any {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// Path
+// A* from one cell to another, steps are the same 8 directions Move takes.
+// Returns the cells to walk through not counting from.
+// Walls always block, other players only if avoidPlayers and Collision.
+// limit is the most cells to look at, 0 for no limit.
+func (m *GameMap) Path(from, to Cell, self *Player, avoidPlayers bool, limit int) ([]Cell, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from.X, from.Y = m.Wrap(from.X, from.Y)
+	to.X, to.Y = m.Wrap(to.X, to.Y)
+	if from == to {
+		return nil, nil
+	}
+	passable := func(c Cell) bool {
+		if _, ok := m.walls[c]; ok {
+			return false
+		}
+		if !avoidPlayers {
+			return true
+		}
+		return m.free(c, self)
+	}
+	if !passable(to) {
+		return nil, ErrMapNoPath
+	}
+	if limit <= 0 {
+		limit = m.Width * m.Height
+	}
+
+	start := &pathNode{c: from, f: m.Distance(from.X, from.Y, to.X, to.Y)}
+	nodes := map[Cell]*pathNode{from: start}
+	came := make(map[Cell]Cell)
+	closed := make(map[Cell]struct{})
+	open := &pathQueue{start}
+
+	for open.Len() > 0 && len(closed) < limit {
+		cur := heap.Pop(open).(*pathNode)
+		if cur.c == to {
+			var path []Cell
+			for c := to; c != from; c = came[c] {
+				path = append(path, c)
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path, nil
+		}
+		closed[cur.c] = struct{}{}
+
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				nx, ny := m.Wrap(cur.c.X+dx, cur.c.Y+dy)
+				next := Cell{nx, ny}
+				if _, done := closed[next]; done || !passable(next) {
+					continue
+				}
+				g := cur.g + 1
+				n, seen := nodes[next]
+				if seen && g >= n.g {
+					continue
+				}
+				came[next] = cur.c
+				f := g + m.Distance(nx, ny, to.X, to.Y)
+				if seen {
+					n.g, n.f = g, f
+					heap.Fix(open, n.index)
+					continue
+				}
+				n = &pathNode{c: next, g: g, f: f}
+				nodes[next] = n
+				heap.Push(open, n)
+			}
+		}
+	}
+	return nil, ErrMapNoPath
+}
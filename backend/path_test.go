@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPathAroundWall(t *testing.T) {
+	m := NewGameMap(20, 20, true)
+	// Wall across x=5 from y=0 to y=8
+	for y := range 9 {
+		_ = m.AddWall(5, y)
+	}
+	path, err := m.Path(Cell{2, 2}, Cell{8, 2}, nil, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range path {
+		if m.Wall(c.X, c.Y) {
+			t.Fatalf("path through wall at %v", c)
+		}
+	}
+	if last := path[len(path)-1]; last != (Cell{8, 2}) {
+		t.Errorf("ends at %v", last)
+	}
+	// Edges wrap so going up over y=0 is shorter than down around the wall.
+	if len(path) > 12 {
+		t.Errorf("path too long %d: %v", len(path), path)
+	}
+
+	// Box in the goal.
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx != 0 || dy != 0 {
+				_ = m.AddWall(15+dx, 15+dy)
+			}
+		}
+	}
+	if _, err = m.Path(Cell{2, 2}, Cell{15, 15}, nil, false, 0); !errors.Is(err, ErrMapNoPath) {
+		t.Errorf("expected no path, got %v", err)
+	}
+
+	// A player in the way only matters when avoiding them.
+	other := &Player{Name: "other"}
+	_ = m.Place(other, 3, 3)
+	if _, err = m.Path(Cell{2, 2}, Cell{3, 3}, nil, true, 0); !errors.Is(err, ErrMapNoPath) {
+		t.Errorf("expected occupied goal to have no path, got %v", err)
+	}
+	if _, err = m.Path(Cell{2, 2}, Cell{3, 3}, nil, false, 0); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMoveToBlocked(t *testing.T) {
+	config := DefaultGameConfig()
+	config.MapWidth, config.MapHeight = 20, 20
+	config.Collision = true
+	config.Items = nil
+	config.Movement.Limit = RateLimit{}
+	w := NewGameWorld("test", NewDatabaseManager(), config)
+
+	// Corridor so there is only the one path.
+	for x := range 7 {
+		_ = w.Map.AddWall(x, 1)
+		_ = w.Map.AddWall(x, 19)
+	}
+
+	s := new(Session)
+	p := &Player{Name: "walker"}
+	_ = w.Map.Place(p, 0, 0)
+	w.Players[s] = p
+
+	if err := w.MoveTo(s, 4, 0); err != nil {
+		t.Fatal(err)
+	}
+	w.tickPaths(time.Now())
+	w.tickPaths(time.Now())
+	if p.X != 2 || p.Y != 0 {
+		t.Fatalf("at %d,%d after two steps", p.X, p.Y)
+	}
+
+	blocker := &Player{Name: "blocker"}
+	_ = w.Map.Place(blocker, 3, 0)
+	w.tickPaths(time.Now())
+	if p.X != 2 || len(p.path) != 0 {
+		t.Errorf("path not cancelled, at %d,%d with %d left", p.X, p.Y, len(p.path))
+	}
+}
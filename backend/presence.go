@@ -0,0 +1,85 @@
+package backend
+
+import (
+	"log"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+// presenceOf
+// Works out presence from the session's input and heartbeat.
+func presenceOf(s *Session, config PresenceConfig, now time.Time) cpnp.Presence {
+	if !s.Responsive(now) {
+		return cpnp.Presence_suspended
+	}
+	quiet := now.Sub(s.LastActive())
+	if config.Away > 0 && quiet >= config.Away {
+		return cpnp.Presence_away
+	}
+	if config.Idle > 0 && quiet >= config.Idle {
+		return cpnp.Presence_idle
+	}
+	return cpnp.Presence_active
+}
+
+// tickPresence
+// Updates every player's presence, broadcasting the ones that changed.
+func (w *GameWorld) tickPresence(now time.Time) {
+	type change struct {
+		s        *Session
+		presence cpnp.Presence
+	}
+	var changed []change
+	w.pmu.RLock()
+	for s, p := range w.Players {
+		presence := presenceOf(s, w.config.Presence, now)
+		p.mu.Lock()
+		if p.Presence != presence {
+			p.Presence = presence
+			changed = append(changed, change{s, presence})
+		}
+		p.mu.Unlock()
+	}
+	w.pmu.RUnlock()
+
+	for _, c := range changed {
+		w.presenceSend(c.s, c.presence)
+	}
+}
+
+// updatePresence
+// Refreshes one player right away, for when they come back.
+func (w *GameWorld) updatePresence(s *Session, now time.Time) {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		return
+	}
+	presence := presenceOf(s, w.config.Presence, now)
+	p.mu.Lock()
+	same := p.Presence == presence
+	p.Presence = presence
+	p.mu.Unlock()
+	if !same {
+		w.presenceSend(s, presence)
+	}
+}
+
+func (w *GameWorld) presenceSend(s *Session, presence cpnp.Presence) {
+	w.writer.mu.Lock()
+	defer w.writer.mu.Unlock()
+	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastPresence)
+	if err != nil {
+		log.Printf("Error creating presence packet: %v", err)
+		return
+	}
+	err = msg.SetId(s.ID.String())
+	if err != nil {
+		log.Printf("Error creating presence packet: %v", err)
+		return
+	}
+	msg.SetPresence(presence)
+	w.Broadcast(msg.Message(), OpCodeBPresence)
+}
@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"testing"
+	"time"
+
+	"simpleWT/backend/cpnp"
+)
+
+func TestPresenceOf(t *testing.T) {
+	config := PresenceConfig{Idle: time.Minute, Away: 5 * time.Minute}
+	now := time.Now()
+	s := &Session{PingWait: PingWaitVal}
+	s.Active.Store(true)
+	s.lastPong.Store(now.UnixNano())
+	s.lastActive.Store(now.UnixNano())
+
+	tests := []struct {
+		after time.Duration
+		want  cpnp.Presence
+	}{
+		{0, cpnp.Presence_active},
+		{2 * time.Minute, cpnp.Presence_idle},
+		{10 * time.Minute, cpnp.Presence_away},
+	}
+	for _, tt := range tests {
+		// Keep the heartbeat healthy, only input goes quiet.
+		later := now.Add(tt.after)
+		s.lastPong.Store(later.UnixNano())
+		if got := presenceOf(s, config, later); got != tt.want {
+			t.Errorf("after %s got %s, want %s", tt.after, got, tt.want)
+		}
+	}
+
+	s.lastPong.Store(now.UnixNano())
+	if got := presenceOf(s, config, now.Add(PingWaitVal)); got != cpnp.Presence_suspended {
+		t.Errorf("missed heartbeat got %s", got)
+	}
+	s.lastPong.Store(now.UnixNano())
+	s.Active.Store(false)
+	if got := presenceOf(s, config, now); got != cpnp.Presence_suspended {
+		t.Errorf("inactive got %s", got)
+	}
+}
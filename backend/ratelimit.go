@@ -0,0 +1,136 @@
+package backend
+
+import (
+	"time"
+)
+
+// RateLimit
+// Rate is how many per second, Burst is how many can be saved up.
+// A zero Rate is no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// tokenBucket
+// Not thread safe, lock whatever owns it.
+type tokenBucket struct {
+	limit  RateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) tokenBucket {
+	return tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.Before(b.last) {
+		return
+	}
+	b.tokens += now.Sub(b.last).Seconds() * b.limit.Rate
+	b.tokens = min(b.tokens, float64(max(b.limit.Burst, 1)))
+	b.last = now
+}
+
+// Allow
+// Takes a token if there is one.
+func (b *tokenBucket) Allow(now time.Time) bool {
+	if b.limit.Rate <= 0 {
+		return true
+	}
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// SetLimit
+// Changes the limit but keeps the saved up tokens.
+func (b *tokenBucket) SetLimit(limit RateLimit, now time.Time) {
+	b.refill(now)
+	b.limit = limit
+	b.tokens = min(b.tokens, float64(max(limit.Burst, 1)))
+}
+
+// EscalationAction
+// What to do to a misbehaving player.
+type EscalationAction int
+
+const (
+	EscalateNone EscalationAction = iota
+	EscalateWarn
+	EscalateThrottle
+	EscalateKick
+)
+
+func (a EscalationAction) String() string {
+	switch a {
+	case EscalateWarn:
+		return "warn"
+	case EscalateThrottle:
+		return "throttle"
+	case EscalateKick:
+		return "kick"
+	default:
+		return "none"
+	}
+}
+
+// Escalation
+// Violation counts to warn, throttle and kick at. 0 turns a step off.
+// Violations are forgotten after Window without any.
+type Escalation struct {
+	Warn     int
+	Throttle int
+	Kick     int
+	Window   time.Duration
+}
+
+// violations
+// Counts violations for an Escalation, lock whatever owns it.
+type violations struct {
+	Count int
+	Total int
+	last  time.Time
+}
+
+// Add
+// Records a violation and returns what should be done about it.
+// Actions are returned once, when the count hits the threshold, except kick.
+func (v *violations) Add(e Escalation, now time.Time) EscalationAction {
+	if e.Window > 0 && !v.last.IsZero() && now.Sub(v.last) > e.Window {
+		v.Count = 0
+	}
+	v.last = now
+	v.Count++
+	v.Total++
+
+	switch {
+	case e.Kick > 0 && v.Count >= e.Kick:
+		return EscalateKick
+	case e.Throttle > 0 && v.Count == e.Throttle:
+		return EscalateThrottle
+	case e.Warn > 0 && v.Count == e.Warn:
+		return EscalateWarn
+	}
+	return EscalateNone
+}
+
+// Reset
+// Player behaved.
+func (v *violations) Reset() {
+	v.Count = 0
+}
+
+// Expired
+// If the window has passed since the last violation.
+func (v *violations) Expired(e Escalation, now time.Time) bool {
+	return e.Window > 0 && !v.last.IsZero() && now.Sub(v.last) > e.Window
+}
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	b := newTokenBucket(RateLimit{Rate: 10, Burst: 3})
+	now := b.last
+
+	for i := range 3 {
+		if !b.Allow(now) {
+			t.Fatalf("burst %d not allowed", i)
+		}
+	}
+	if b.Allow(now) {
+		t.Fatal("allowed past burst")
+	}
+	if !b.Allow(now.Add(100 * time.Millisecond)) {
+		t.Fatal("not refilled")
+	}
+
+	unlimited := newTokenBucket(RateLimit{})
+	for range 100 {
+		if !unlimited.Allow(now) {
+			t.Fatal("zero rate should not limit")
+		}
+	}
+}
+
+func TestEscalation(t *testing.T) {
+	e := Escalation{Warn: 1, Throttle: 2, Kick: 3, Window: time.Second}
+	var v violations
+	now := time.Now()
+
+	want := []EscalationAction{EscalateWarn, EscalateThrottle, EscalateKick}
+	for i, w := range want {
+		if got := v.Add(e, now); got != w {
+			t.Errorf("violation %d got %s want %s", i, got, w)
+		}
+	}
+
+	// Window passed, starts over
+	if got := v.Add(e, now.Add(2*time.Second)); got != EscalateWarn {
+		t.Errorf("after window got %s", got)
+	}
+	if v.Total != 4 {
+		t.Errorf("total %d", v.Total)
+	}
+}
@@ -0,0 +1,104 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrRenameTooSoon = errors.New("renaming too often")
+)
+
+// renameLimits
+// Per user rename budget, kept across worlds.
+type renameLimits struct {
+	limit   RateLimit
+	buckets map[uuid.UUID]*tokenBucket
+}
+
+func (r *renameLimits) Allow(id uuid.UUID, now time.Time) bool {
+	b, ok := r.buckets[id]
+	if !ok {
+		nb := newTokenBucket(r.limit)
+		b = &nb
+		r.buckets[id] = b
+	}
+	return b.Allow(now)
+}
+
+// Rename
+// Changes a session's name in the user database and the world it is in.
+func (m *WorldManager) Rename(s *Session, name string) error {
+	// Bad names don't use up the limit.
+	_, err := CleanName(name)
+	if err != nil {
+		return err
+	}
+	m.remu.Lock()
+	ok := m.renames.Allow(s.ID, time.Now())
+	m.remu.Unlock()
+	if !ok {
+		return ErrRenameTooSoon
+	}
+
+	old, name, err := m.db.RenameUser(s.ID, name)
+	if err != nil {
+		return err
+	}
+	log.Printf("Renamed %s to %s, ID: %s\n", old, name, s.ID)
+	if w := m.WorldOf(s); w != nil {
+		w.renamed(s, old, name)
+	}
+	return nil
+}
+
+func (m *WorldManager) HandleRename(s *Session, payload []byte) {
+	msg, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientRename)
+	if !valid {
+		return
+	}
+	name, err := msg.Name()
+	if err != nil {
+		return
+	}
+	err = m.Rename(s, name)
+	if err != nil {
+		if w := m.WorldOf(s); w != nil {
+			w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Can't rename to %q: %v", name, err))
+		}
+	}
+}
+
+// renamed
+// Updates the player and tells everyone.
+func (w *GameWorld) renamed(s *Session, old, name string) {
+	w.pmu.RLock()
+	p, ok := w.Players[s]
+	w.pmu.RUnlock()
+	if !ok {
+		// Spectators have no one to rename.
+		return
+	}
+	p.mu.Lock()
+	p.Name = name
+	p.mu.Unlock()
+	w.record(Event{Kind: EventRename, ID: s.ID.String(), Name: name, Text: old})
+
+	w.writer.mu.Lock()
+	defer w.writer.mu.Unlock()
+	msg, err := NewMessage(w.writer, cpnp.NewRootGameBroadcastRename)
+	if err != nil {
+		log.Printf("Error creating rename packet: %v\n", err)
+		return
+	}
+	_ = msg.SetId(s.ID.String())
+	_ = msg.SetOld(old)
+	_ = msg.SetName(name)
+	w.Broadcast(msg.Message(), OpCodeBRename)
+}
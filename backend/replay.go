@@ -0,0 +1,172 @@
+package backend
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ReplayEntity
+// A player or NPC as the log last saw them.
+type ReplayEntity struct {
+	ID     string
+	Name   string
+	X, Y   int
+	Online bool
+
+	// Only so the map can track them.
+	player *Player
+}
+
+// ReplayState
+// A world rebuilt from its event log.
+type ReplayState struct {
+	World string
+	Seed  uint64
+	// Tick of the last event applied.
+	Tick   uint64
+	Events int
+
+	Map      *GameMap
+	Entities map[string]*ReplayEntity
+	Items    map[uint32]Item
+	Chat     []Event
+
+	GarbagePassed int
+	GarbageFailed int
+
+	// Problems things in the log that don't fit the rebuilt map.
+	// Replays of a good log have none.
+	Problems []string
+}
+
+// Replay
+// Rebuilds a world from an event log up to and including tick, 0 for all of it.
+// If the world was made more than once, say the server restarted, the last one wins.
+func Replay(r io.Reader, world string, tick uint64) (*ReplayState, error) {
+	var state *ReplayState
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		var e Event
+		err := json.Unmarshal(scanner.Bytes(), &e)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if e.World != world {
+			continue
+		}
+		if tick != 0 && e.Tick > tick {
+			continue
+		}
+		if e.Kind == EventWorld {
+			// Restarted worlds start over.
+			state = newReplayState(e)
+		}
+		if state == nil {
+			// Log started after the world did.
+			continue
+		}
+		state.apply(e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, ErrWorldNotFound
+	}
+	return state, nil
+}
+
+func newReplayState(e Event) *ReplayState {
+	// Same order as newGameWorld so the seed gives the same walls.
+	m := NewGameMap(e.Width, e.Height, e.Collision)
+	m.Seed(e.Seed)
+	m.RandomWalls(e.Walls)
+	return &ReplayState{
+		World:    e.World,
+		Seed:     e.Seed,
+		Map:      m,
+		Entities: make(map[string]*ReplayEntity),
+		Items:    make(map[uint32]Item),
+	}
+}
+
+func (r *ReplayState) problem(e Event, format string, args ...any) {
+	r.Problems = append(r.Problems, fmt.Sprintf("tick %d %s %s: ", e.Tick, e.Kind, e.Name)+fmt.Sprintf(format, args...))
+}
+
+func (r *ReplayState) apply(e Event) {
+	r.Tick = e.Tick
+	r.Events++
+	switch e.Kind {
+	case EventConnect:
+		ent, ok := r.Entities[e.ID]
+		if !ok {
+			ent = &ReplayEntity{ID: e.ID, player: &Player{}}
+			r.Entities[e.ID] = ent
+		}
+		ent.Name = e.Name
+		ent.player.Name = e.Name
+		ent.Online = true
+		r.place(ent, e)
+	case EventDisconnect:
+		ent, ok := r.Entities[e.ID]
+		if !ok {
+			r.problem(e, "never connected")
+			return
+		}
+		ent.Online = false
+		r.Map.Remove(ent.player)
+	case EventMove:
+		ent, ok := r.Entities[e.ID]
+		if !ok || !ent.Online {
+			r.problem(e, "moved while not connected")
+			return
+		}
+		if r.Map.Distance(ent.X, ent.Y, e.X, e.Y) > 1 {
+			r.problem(e, "jumped from %d,%d to %d,%d", ent.X, ent.Y, e.X, e.Y)
+		}
+		r.place(ent, e)
+	case EventRename:
+		ent, ok := r.Entities[e.ID]
+		if !ok {
+			r.problem(e, "renamed while not connected")
+			return
+		}
+		ent.Name = e.Name
+		ent.player.Name = e.Name
+	case EventChat:
+		r.Chat = append(r.Chat, e)
+	case EventGarbage:
+		if e.Ok {
+			r.GarbagePassed++
+		} else {
+			r.GarbageFailed++
+		}
+	case EventItemSpawn:
+		if r.Map.Wall(e.X, e.Y) {
+			r.problem(e, "item %d in a wall at %d,%d", e.Item, e.X, e.Y)
+		}
+		r.Items[e.Item] = Item{ID: e.Item, Kind: e.Text, X: e.X, Y: e.Y}
+	case EventItemPickup:
+		if _, ok := r.Items[e.Item]; !ok {
+			r.problem(e, "picked up missing item %d", e.Item)
+		}
+		delete(r.Items, e.Item)
+	}
+}
+
+func (r *ReplayState) place(ent *ReplayEntity, e Event) {
+	if r.Map.Wall(e.X, e.Y) {
+		r.problem(e, "in a wall at %d,%d", e.X, e.Y)
+	}
+	err := r.Map.Place(ent.player, e.X, e.Y)
+	if err != nil {
+		r.problem(e, "%v at %d,%d", err, e.X, e.Y)
+	}
+	ent.X, ent.Y = e.X, e.Y
+}
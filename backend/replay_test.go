@@ -0,0 +1,81 @@
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	events, err := OpenEventLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultGameConfig()
+	config.MapWidth, config.MapHeight = 30, 30
+	config.Walls = 40
+	config.Seed = 1234
+	config.NPC.Speed = RateLimit{}
+	w := newGameWorld("test", NewDatabaseManager(), config, events)
+	// Wanderers so there are moves after the first tick.
+	for range 5 {
+		if _, err = w.AddNPC("wanderer", &Wander{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 20 {
+		w.tick()
+	}
+	if err = events.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	state, err := Replay(f, "test", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(state.Problems) > 0 {
+		t.Errorf("problems: %v", state.Problems)
+	}
+	if !slices.Equal(state.Map.Walls(), w.Map.Walls()) {
+		t.Error("seed didn't give the same walls")
+	}
+	if len(state.Items) != len(w.Items()) {
+		t.Errorf("items %d, world has %d", len(state.Items), len(w.Items()))
+	}
+	for id, n := range w.NPCs {
+		ent, ok := state.Entities[id.String()]
+		if !ok {
+			t.Fatalf("missing NPC %s", n.Player.Name)
+		}
+		x, y := n.position()
+		if ent.X != x || ent.Y != y {
+			t.Errorf("%s at %d,%d, replay has %d,%d", n.Player.Name, x, y, ent.X, ent.Y)
+		}
+	}
+
+	// Part way through only has the moves up to then.
+	_, _ = f.Seek(0, 0)
+	early, err := Replay(f, "test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if early.Tick > 1 || early.Events >= state.Events {
+		t.Errorf("tick 1 replay went to tick %d with %d events", early.Tick, early.Events)
+	}
+
+	// Same seed, same world.
+	again := NewGameWorld("test", NewDatabaseManager(), config)
+	if !slices.Equal(again.Map.Walls(), w.Map.Walls()) {
+		t.Error("same seed different walls")
+	}
+}
@@ -3,6 +3,7 @@ package backend
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"sync"
 	"sync/atomic"
@@ -38,14 +39,39 @@ type Session struct {
 	// IP of connection
 	IP string
 
+	// Spectator watches the world without being in it.
+	Spectator bool
+	// Follow who a spectator starts out following.
+	Follow string
+
+	// GarbageHashes and GarbageEncodings the client supports, from login.
+	GarbageHashes    []cpnp.GarbageHash
+	GarbageEncodings []cpnp.GarbageEncoding
+	GarbageModes     []cpnp.GarbageMode
+	// garbageDifficulty work mode difficulty override, 0 uses the policy.
+	garbageDifficulty atomic.Int32
+
+	// handled packets and total handlerTime, for the load signals.
+	handled     atomic.Int64
+	handlerTime atomic.Int64
+
+	// garbageStats counters across worlds, see GarbageStats.
+	garbageStats GarbageStats
+	gsmu         sync.Mutex
+
+	// garbage policy override, nil uses the world's.
+	garbage atomic.Pointer[GarbagePolicy]
+
 	// Connection active, idea is to be used for reconnects.
 	// Not sure if an atomic here is correct
 	Active atomic.Bool
-	// Only updated on going inactive.
-	LastActive time.Time
+	// Unix nano of the last player input or going inactive, see Touch.
+	lastActive atomic.Int64
 
 	stream *webtransport.Stream
 	conn   *webtransport.Session
+	// out where Send writes, the stream once started.
+	out io.Writer
 
 	// This is probably crap
 	// It is, for every session we have to save each handler func pointer.
@@ -62,15 +88,17 @@ type Session struct {
 	// writeBuffer []byte
 
 	// Ping info
-	PingWait    time.Duration
-	PingPeriod  time.Duration
-	lastPing    atomic.Int64
-	missedPings int
+	PingWait   time.Duration
+	PingPeriod time.Duration
+	lastPing   atomic.Int64
+	lastPong   atomic.Int64
 
 	// Close channel
 	// Maybe do a context?
 	// Couldn't figure those out though
 	Closing chan struct{}
+	// cmu guards Closing, stream and conn, a reconnect swaps them while the old ones wind down.
+	cmu sync.Mutex
 }
 
 type SessionManager struct {
@@ -104,8 +132,6 @@ func (m *SessionManager) CreateSession(id uuid.UUID, ip string, conn *webtranspo
 		ID: id,
 		IP: ip,
 
-		LastActive: time.Now(),
-
 		conn: conn,
 
 		handlers: make(map[uint16]SessionPacketHandlerFunc),
@@ -124,6 +150,7 @@ func (m *SessionManager) CreateSession(id uuid.UUID, ip string, conn *webtranspo
 	}
 
 	session.lastPing.Store(-1)
+	session.Touch()
 	session.AddHandler(OpCodeHeartbeat, session.HandlePong)
 
 	m.sessions[id] = session
@@ -151,9 +178,43 @@ func (m *SessionManager) GetValidSession(id uuid.UUID, ip string) (*Session, err
 	return session, nil
 }
 
+// SetGarbagePolicy
+// Overrides the garbage policy for this session from the next challenge, nil to undo.
+func (s *Session) SetGarbagePolicy(g *GarbagePolicy) {
+	s.garbage.Store(g)
+}
+
+// SetGarbageDifficulty
+// Work mode leading zero bits for this session from the next challenge, 0 to undo.
+// Something to raise on anyone flooding.
+func (s *Session) SetGarbageDifficulty(bits int) {
+	s.garbageDifficulty.Store(int32(min(max(bits, 0), maxGarbageDifficulty)))
+}
+
+// GarbageDifficulty
+// The override, 0 if there isn't one.
+func (s *Session) GarbageDifficulty() int {
+	return int(s.garbageDifficulty.Load())
+}
+
+// GarbagePolicy
+// The override, nil if there isn't one.
+func (s *Session) GarbagePolicy() *GarbagePolicy {
+	return s.garbage.Load()
+}
+
+// Online
+// If a user has a session that is still connected.
+func (m *SessionManager) Online(id uuid.UUID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	session, ok := m.sessions[id]
+	return ok && session.Active.Load()
+}
+
 // Run
 // Prunes sessions every minute
-func (m *SessionManager) Run(world *GameWorld) {
+func (m *SessionManager) Run(worlds *WorldManager) {
 	ticker := time.NewTicker(time.Minute)
 	defer ticker.Stop()
 	for {
@@ -161,23 +222,23 @@ func (m *SessionManager) Run(world *GameWorld) {
 		case <-m.Closing:
 			return
 		case <-ticker.C:
-			m.pruneInactive(world)
+			m.pruneInactive(worlds)
 		}
 	}
 }
 
 // pruneInactive
 // Removes sessions that have been inactive for 5 minutes
-func (m *SessionManager) pruneInactive(world *GameWorld) {
+func (m *SessionManager) pruneInactive(worlds *WorldManager) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	for _, session := range m.sessions {
 		if session.Active.Load() {
 			continue
 		}
-		if session.LastActive.Add(time.Minute * 5).Before(time.Now()) {
-			world.Disconnect(session)
-			// Save to disk or something here?
+		if session.LastActive().Add(time.Minute * 5).Before(time.Now()) {
+			worlds.Disconnect(session)
+			// Disconnect keeps where they were for the snapshot.
 			delete(m.sessions, session.ID)
 		}
 	}
@@ -187,54 +248,82 @@ func (m *SessionManager) pruneInactive(world *GameWorld) {
 // Starts a session
 // Only fails if it can't open a stream.
 func (s *Session) Start() error {
-	control, err := s.conn.OpenStream()
+	s.cmu.Lock()
+	conn := s.conn
+	s.cmu.Unlock()
+	control, err := conn.OpenStream()
 	if err != nil {
 		// What code?
-		_ = s.conn.CloseWithError(500, "Error opening control stream")
+		_ = conn.CloseWithError(500, "Error opening control stream")
 		return fmt.Errorf("%w: %w", ErrSessionFailedToStart, err)
 	}
+	closing := make(chan struct{})
+	s.cmu.Lock()
 	s.stream = control
+	s.Closing = closing
+	s.cmu.Unlock()
+	// Send holds the writer lock.
+	s.writer.mu.Lock()
+	s.out = control
+	s.writer.mu.Unlock()
 	s.Active.Store(true)
-	s.Closing = make(chan struct{})
+	s.lastPong.Store(time.Now().UnixNano())
 
-	go s.HandleStream(s.stream)
-	go s.StartHeartbeat()
+	go s.HandleStream(control)
+	go s.StartHeartbeat(closing)
 
 	return nil
 }
 
+// current
+// Closing and the stream it's for, a reconnect swaps both.
+func (s *Session) current() (chan struct{}, *webtransport.Stream) {
+	s.cmu.Lock()
+	defer s.cmu.Unlock()
+	return s.Closing, s.stream
+}
+
 // Reconnect
 // Badly implemented reconnect a session.
+// The old connection could be dead without us knowing, it gets closed either way.
 func (s *Session) Reconnect(conn *webtransport.Session) error {
-	// This is probably bad.
-	err := s.Close()
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrSessionFailedToStart, err)
-	}
+	// Closing a dead connection can fail, CloseWithReason logs it.
+	_ = s.Close()
+	s.cmu.Lock()
 	s.conn = conn
+	s.cmu.Unlock()
 	return s.Start()
 }
 
 // Close
 // One day this will gracefully close a session
 func (s *Session) Close() error {
+	return s.CloseWithReason("Shutdown")
+}
+
+// CloseWithReason
+// Close but the client gets told why.
+func (s *Session) CloseWithReason(reason string) error {
 	s.Active.Store(false)
-	s.LastActive = time.Now()
-	if s.Closing != nil {
-		close(s.Closing)
-		s.Closing = nil
+	s.Touch()
+	s.cmu.Lock()
+	closing, stream, conn := s.Closing, s.stream, s.conn
+	s.Closing = nil
+	s.cmu.Unlock()
+	if closing != nil {
+		close(closing)
 	}
 
 	var err error
-	if s.stream != nil {
+	if stream != nil {
 		// What codes?
-		s.stream.CancelWrite(ErrSessionStreamClosed)
-		s.stream.CancelRead(ErrSessionStreamClosed)
+		stream.CancelWrite(ErrSessionStreamClosed)
+		stream.CancelRead(ErrSessionStreamClosed)
 
-		_ = s.stream.Close()
+		_ = stream.Close()
 	}
 
-	err = s.conn.CloseWithError(500, "Shutdown")
+	err = conn.CloseWithError(500, reason)
 	if err != nil {
 		log.Printf("Error closing session: %v\n", err)
 	}
@@ -242,20 +331,44 @@ func (s *Session) Close() error {
 	return err
 }
 
+// Touch
+// Marks the session as active now.
+func (s *Session) Touch() {
+	s.lastActive.Store(time.Now().UnixNano())
+}
+
+// LastActive
+// Last player input, or when the session went inactive.
+func (s *Session) LastActive() time.Time {
+	return time.Unix(0, s.lastActive.Load())
+}
+
+// Responsive
+// Active and answered a heartbeat within PingWait.
+func (s *Session) Responsive(now time.Time) bool {
+	if !s.Active.Load() {
+		return false
+	}
+	return now.Sub(time.Unix(0, s.lastPong.Load())) < s.PingWait
+}
+
 // HandleStream
 // Just wrapping the error in packet.HandleStream
 func (s *Session) HandleStream(stream *webtransport.Stream) {
-	err := HandleStream(stream, s.incoming, s.Closing)
-	if err != nil {
+	closing, _ := s.current()
+	err := HandleStream(stream, s.incoming, closing)
+	// A reconnect already moved on to a new stream, leave it alone.
+	if _, now := s.current(); err != nil && now == stream {
 		log.Printf("Error handling stream: %v\n", err)
 		_ = s.Close()
 	}
 }
 
 // StartHeartbeat
-// Starts the heartbeat loop
-func (s *Session) StartHeartbeat() {
-	if s.stream == nil {
+// Starts the heartbeat loop, runs until closing does.
+// Takes the channel so a reconnect's new one doesn't keep an old loop going.
+func (s *Session) StartHeartbeat(closing <-chan struct{}) {
+	if closing == nil {
 		return
 	}
 
@@ -274,14 +387,14 @@ func (s *Session) StartHeartbeat() {
 		log.Printf("Error heartbeat: %v", err)
 	}
 	wait.Reset(s.PingWait)
-	s.missedPings = 0
+	missedPings := 0
 
 	for {
 		select {
-		case <-s.Closing:
+		case <-closing:
 			return
 		case <-wait.C:
-			if s.missedPings >= 3 {
+			if missedPings >= 3 {
 				// Is this ok?
 				_ = s.Close()
 				return
@@ -310,19 +423,24 @@ func (s *Session) Run() {
 	if !s.Active.Load() {
 		return
 	}
+	// Until this connection closes, a reconnect starts another.
+	closing, _ := s.current()
 	// Maybe return something?
 	for {
 		select {
-		case <-s.Closing:
+		case <-closing:
 			return
 		case packet := <-s.incoming:
 			fun, ok := s.handlers[packet.Header.OpCode]
 			if !ok {
 				continue
 			}
+			start := time.Now()
 			s.reader.mu.Lock()
 			fun(s, packet.Payload)
 			s.reader.mu.Unlock()
+			s.handlerTime.Add(int64(time.Since(start)))
+			s.handled.Add(1)
 		}
 	}
 }
@@ -333,6 +451,16 @@ func (s *Session) AddHandler(opcode uint16, handler SessionPacketHandlerFunc) {
 	s.handlers[opcode] = handler
 }
 
+// Send
+// Writes a message to the session stream.
+// Not started yet is an error so callers log it instead of it going nowhere.
+func (s *Session) Send(pk PacketWriteSender, msg *capnp.Message, opcode uint16) (int, error) {
+	if s.out == nil {
+		return 0, ErrSessionInactive
+	}
+	return SendStream(pk, s.out, msg, opcode)
+}
+
 // QueueMessage
 // Builds a message to send
 func QueueMessage[T CapnpMessage](s *Session, opcode uint16, ctor func(*capnp.Segment) (T, error), build func(T) error) error {
@@ -360,6 +488,6 @@ func QueueMessage[T CapnpMessage](s *Session, opcode uint16, ctor func(*capnp.Se
 	// 	log.Printf("Error setting write deadline: %v", err)
 	// 	return fmt.Errorf("%w", err)
 	// }
-	_, err = SendStream(s.writer, s.stream, msg.Message(), opcode)
+	_, err = s.Send(s.writer, msg.Message(), opcode)
 	return err
 }
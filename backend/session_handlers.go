@@ -2,6 +2,7 @@ package backend
 
 import (
 	"log"
+	"time"
 
 	"simpleWT/backend/cpnp"
 )
@@ -12,6 +13,7 @@ func (s *Session) HandlePong(_ *Session, payload []byte) {
 		log.Println("Invalid ping.", s.ID)
 		return
 	}
+	s.lastPong.Store(time.Now().UnixNano())
 	// I had this wrong at one point and time was in the realm of 400-900ms on the same machine.
 	// I thought I did something super wrong. But nope, just storing the unix time wrong.
 	// sent := time.Unix(0, s.lastPing.Load())
@@ -0,0 +1,369 @@
+package backend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+// SnapshotVersion
+// Bump when the snapshot layout changes and add a migration for the old one.
+// Adding fields doesn't need a bump, missing ones load as zero.
+const SnapshotVersion = 1
+
+var (
+	ErrSnapshotVersion = errors.New("snapshot version not supported")
+)
+
+// snapshotMigrations
+// Upgrades a snapshot from the version it's keyed by to the next one.
+var snapshotMigrations = map[int]func(map[string]json.RawMessage) error{}
+
+// Snapshot
+// Everything needed to bring the server back after a restart.
+type Snapshot struct {
+	Version int       `json:"version"`
+	Saved   time.Time `json:"saved"`
+
+	// Users ID to name.
+	Users map[string]string `json:"users"`
+	// Secrets ID to hex sha256 of their login secret.
+	Secrets map[string]string `json:"secrets,omitempty"`
+
+	Worlds []WorldSnapshot `json:"worlds"`
+	Global []ChatSnapshot  `json:"global"`
+	// Players where each user was, by user ID.
+	Players map[string]PlayerSnapshot `json:"players"`
+}
+
+type WorldSnapshot struct {
+	Name string         `json:"name"`
+	Seed uint64         `json:"seed"`
+	Chat []ChatSnapshot `json:"chat"`
+}
+
+type PlayerSnapshot struct {
+	World     string    `json:"world"`
+	Name      string    `json:"name"`
+	X         int       `json:"x"`
+	Y         int       `json:"y"`
+	Inventory Inventory `json:"inventory,omitempty"`
+}
+
+type ChatSnapshot struct {
+	Seq    uint64    `json:"seq"`
+	Time   time.Time `json:"time"`
+	Name   string    `json:"name"`
+	ID     string    `json:"id"`
+	Kind   string    `json:"kind"`
+	Text   string    `json:"text"`
+	Target string    `json:"target,omitempty"`
+}
+
+// LoadSnapshot
+// Reads a snapshot, migrating it from older versions.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+	var version int
+	err = json.Unmarshal(raw["version"], &version)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSnapshotVersion, err)
+	}
+	for version < SnapshotVersion {
+		migrate, ok := snapshotMigrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration from %d", ErrSnapshotVersion, version)
+		}
+		err = migrate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("migrating from %d: %w", version, err)
+		}
+		version++
+		raw["version"], _ = json.Marshal(version)
+	}
+	if version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: %d is newer than %d", ErrSnapshotVersion, version, SnapshotVersion)
+	}
+
+	data, err = json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	snap := new(Snapshot)
+	err = json.Unmarshal(data, snap)
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Save
+// Writes to a temp file first so a crash mid save keeps the old one.
+func (snap *Snapshot) Save(path string) error {
+	data, err := json.MarshalIndent(snap, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func chatSnapshots(h *ChatHistory) []ChatSnapshot {
+	entries, _ := h.Page(0, h.Len())
+	chats := make([]ChatSnapshot, 0, len(entries))
+	for _, e := range entries {
+		chats = append(chats, ChatSnapshot{
+			Seq:    e.Seq,
+			Time:   e.Time,
+			Name:   e.Name,
+			ID:     e.ID,
+			Kind:   e.Kind.String(),
+			Text:   e.Text,
+			Target: e.Target,
+		})
+	}
+	return chats
+}
+
+func restoreChat(h *ChatHistory, chats []ChatSnapshot) {
+	for _, c := range chats {
+		h.restore(ChatEntry{
+			ChatMessage: ChatMessage{
+				Name:   c.Name,
+				ID:     c.ID,
+				Kind:   cpnp.ChatKindFromString(c.Kind),
+				Text:   c.Text,
+				Target: c.Target,
+			},
+			Seq:  c.Seq,
+			Time: c.Time,
+		})
+	}
+}
+
+// Snapshot
+// Current state of every world plus players who left.
+func (m *WorldManager) Snapshot() *Snapshot {
+	snap := &Snapshot{
+		Version: SnapshotVersion,
+		Saved:   time.Now(),
+		Users:   make(map[string]string),
+		Secrets: make(map[string]string),
+		Global:  chatSnapshots(m.chat.global),
+		Players: make(map[string]PlayerSnapshot),
+	}
+	for id, name := range m.db.Users.Snapshot() {
+		snap.Users[id.String()] = name
+	}
+	for id, hash := range m.db.Users.Secrets() {
+		snap.Secrets[id.String()] = hex.EncodeToString(hash[:])
+	}
+
+	m.rmu.Lock()
+	for id, p := range m.resume {
+		snap.Players[id.String()] = p
+	}
+	m.rmu.Unlock()
+
+	for _, name := range m.Names() {
+		w, ok := m.Get(name)
+		if !ok {
+			continue
+		}
+		snap.Worlds = append(snap.Worlds, WorldSnapshot{
+			Name: w.Name,
+			Seed: w.Seed,
+			Chat: chatSnapshots(w.history),
+		})
+		w.pmu.RLock()
+		for s := range w.Players {
+			if p, ok := w.playerSnapshot(s); ok {
+				snap.Players[s.ID.String()] = p
+			}
+		}
+		w.pmu.RUnlock()
+	}
+	return snap
+}
+
+// restore
+// Loads a snapshot into a manager that has no worlds yet.
+func (m *WorldManager) restore(snap *Snapshot) {
+	users := make(map[uuid.UUID]string, len(snap.Users))
+	for id, name := range snap.Users {
+		uid, err := uuid.FromString(id)
+		if err != nil {
+			log.Printf("Snapshot: bad user ID %q\n", id)
+			continue
+		}
+		users[uid] = name
+	}
+	m.db.Users.Restore(users)
+
+	secrets := make(map[uuid.UUID][sha256.Size]byte, len(snap.Secrets))
+	for id, h := range snap.Secrets {
+		uid, err := uuid.FromString(id)
+		hash, herr := hex.DecodeString(h)
+		if err != nil || herr != nil || len(hash) != sha256.Size {
+			log.Printf("Snapshot: bad secret for user %q\n", id)
+			continue
+		}
+		secrets[uid] = [sha256.Size]byte(hash)
+	}
+	m.db.Users.RestoreSecrets(secrets)
+
+	for id, p := range snap.Players {
+		uid, err := uuid.FromString(id)
+		if err != nil {
+			log.Printf("Snapshot: bad player ID %q\n", id)
+			continue
+		}
+		m.resume[uid] = p
+	}
+
+	restoreChat(m.chat.global, snap.Global)
+	for _, ws := range snap.Worlds {
+		m.seeds[ws.Name] = ws.Seed
+		m.chats[ws.Name] = ws.Chat
+	}
+}
+
+// SaveSnapshot
+// Saves to the configured path, nothing if there isn't one.
+func (m *WorldManager) SaveSnapshot() error {
+	if m.config.Snapshot.Path == "" {
+		return nil
+	}
+	return m.Snapshot().Save(m.config.Snapshot.Path)
+}
+
+// runSnapshots
+// Saves every Snapshot.Every until Shutdown.
+func (m *WorldManager) runSnapshots() {
+	if m.config.Snapshot.Path == "" || m.config.Snapshot.Every <= 0 {
+		return
+	}
+	ticker := time.NewTicker(m.config.Snapshot.Every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.closing:
+			return
+		case <-ticker.C:
+			err := m.SaveSnapshot()
+			if err != nil {
+				log.Printf("Error saving snapshot: %v\n", err)
+			}
+		}
+	}
+}
+
+// takeResume
+// Where a user left off, only handed out once.
+// The inventory goes to any world, here is false if the position is from another one.
+func (m *WorldManager) takeResume(id uuid.UUID, world string) (p PlayerSnapshot, here, ok bool) {
+	m.rmu.Lock()
+	defer m.rmu.Unlock()
+	p, ok = m.resume[id]
+	if !ok {
+		return PlayerSnapshot{}, false, false
+	}
+	delete(m.resume, id)
+	return p, p.World == world, true
+}
+
+// remember
+// Keeps a session's player for resume before it leaves a world.
+func (m *WorldManager) remember(w *GameWorld, s *Session) {
+	w.pmu.RLock()
+	p, ok := w.playerSnapshot(s)
+	w.pmu.RUnlock()
+	if !ok {
+		return
+	}
+	m.rmu.Lock()
+	m.resume[s.ID] = p
+	m.rmu.Unlock()
+}
+
+// resumeWorld
+// The world a user was last in, empty if unknown.
+func (m *WorldManager) resumeWorld(id uuid.UUID) string {
+	m.rmu.Lock()
+	defer m.rmu.Unlock()
+	return m.resume[id].World
+}
+
+// playerSnapshot
+// expects w.pmu to be held.
+func (w *GameWorld) playerSnapshot(s *Session) (PlayerSnapshot, bool) {
+	p, ok := w.Players[s]
+	if !ok {
+		return PlayerSnapshot{}, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PlayerSnapshot{
+		World:     w.Name,
+		Name:      p.Name,
+		X:         p.X,
+		Y:         p.Y,
+		Inventory: maps.Clone(p.Inventory),
+	}, true
+}
+
+// spawnPlayer
+// Puts a player back where they left off, or somewhere random.
+func (w *GameWorld) spawnPlayer(s *Session, p *Player) error {
+	if w.manager == nil {
+		return w.Map.Spawn(p)
+	}
+	snap, here, ok := w.manager.takeResume(s.ID, w.Name)
+	if ok {
+		p.Inventory = snap.Inventory
+		if here && w.Map.Place(p, snap.X, snap.Y) == nil {
+			return nil
+		}
+	}
+	err := w.Map.Spawn(p)
+	if err != nil && ok {
+		// Not placed, it can be resumed somewhere else.
+		w.manager.rmu.Lock()
+		w.manager.resume[s.ID] = snap
+		w.manager.rmu.Unlock()
+	}
+	return err
+}
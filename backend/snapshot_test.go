@@ -0,0 +1,85 @@
+package backend
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	config := DefaultGameConfig()
+	config.Snapshot.Path = filepath.Join(t.TempDir(), "snapshot.json")
+	config.Worlds = []WorldConfig{{Name: "other", MapWidth: 20, MapHeight: 20, Walls: 30}}
+
+	db := NewDatabaseManager()
+	worlds := NewWorldManager(db, config)
+	sessions := NewSessionManager()
+	s := testSession(t, db, sessions)
+	name, _ := db.GetUserByID(s.ID)
+	if err := worlds.Connect(s, "other"); err != nil {
+		t.Fatal(err)
+	}
+	other, _ := worlds.Get("other")
+	p := other.Players[s]
+	p.Inventory = Inventory{"coin": 3}
+	x, y := p.X, p.Y
+	other.history.Add(ChatMessage{Name: name, Text: "remember me"}, time.Now())
+	keeper, secret, err := db.LoginUser("Secret Keeper", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	worlds.Disconnect(s)
+	worlds.Shutdown()
+
+	// Fresh server, same file.
+	db = NewDatabaseManager()
+	worlds = NewWorldManager(db, config)
+	uid, err := db.GetUser(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uid != s.ID {
+		t.Fatal("user not restored")
+	}
+	if again, _, err := db.LoginUser("secret keeper", secret); err != nil || again != keeper {
+		t.Errorf("secret not restored: %v", err)
+	}
+	again, _ := worlds.Get("other")
+	if !slices.Equal(again.Map.Walls(), other.Map.Walls()) {
+		t.Error("walls changed")
+	}
+	if entries, _ := again.history.Page(0, 10); len(entries) != 1 || entries[0].Text != "remember me" {
+		t.Errorf("chat not restored %+v", entries)
+	}
+
+	s = testStart(NewSessionManager().CreateSession(uid, "127.0.0.1", nil))
+	if err = worlds.Connect(s, ""); err != nil {
+		t.Fatal(err)
+	}
+	if worlds.WorldOf(s) != again {
+		t.Fatal("didn't go back to the same world")
+	}
+	p = again.Players[s]
+	if p.X != x || p.Y != y || p.Inventory["coin"] != 3 {
+		t.Errorf("at %d,%d with %v, want %d,%d and 3 coins", p.X, p.Y, p.Inventory, x, y)
+	}
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	data, _ := json.Marshal(map[string]any{"version": SnapshotVersion + 1})
+	_ = os.WriteFile(path, data, 0o644)
+	if _, err := LoadSnapshot(path); !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("expected version error, got %v", err)
+	}
+
+	data, _ = json.Marshal(map[string]any{"version": 0})
+	_ = os.WriteFile(path, data, 0o644)
+	if _, err := LoadSnapshot(path); !errors.Is(err, ErrSnapshotVersion) {
+		t.Errorf("expected missing migration, got %v", err)
+	}
+}
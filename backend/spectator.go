@@ -0,0 +1,175 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"capnproto.org/go/capnp/v3"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrNotSpectator  = errors.New("not a spectator")
+	ErrFollowMissing = errors.New("no player to follow")
+)
+
+// Spectator
+// A session watching a world without a player in it.
+// Gets the world, moves and chat but isn't in player lists and sends no inputs.
+type Spectator struct {
+	// Follow name or ID being followed, empty for the whole world.
+	Follow string
+	target *Session
+}
+
+// connectSpectator
+// Connect for spectators, no player, no garbage.
+func (w *GameWorld) connectSpectator(s *Session) {
+	// Read only handlers, nothing that changes the world.
+	s.AddHandler(OpCodeCChatHistory, w.HandleClientChatHistory)
+	s.AddHandler(OpCodeCSpectate, w.HandleClientSpectate)
+
+	w.spmu.Lock()
+	if _, ok := w.Spectators[s]; ok {
+		w.spmu.Unlock()
+		return
+	}
+	w.Spectators[s] = &Spectator{}
+	w.spmu.Unlock()
+	log.Printf("Spectating: ID: %s, World: %s\n", s.ID, w.Name)
+
+	w.sendSpectatorState(s)
+	if s.Follow != "" {
+		err := w.Follow(s, s.Follow)
+		if err != nil {
+			w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Can't follow %q: %v", s.Follow, err))
+		}
+	}
+}
+
+func (w *GameWorld) sendSpectatorState(s *Session) {
+	w.sendWorld(s)
+	w.sendPlayers(s)
+	w.sendChatBacklog(s)
+	w.sendItems(s)
+}
+
+// disconnectSpectator
+// False if the session wasn't spectating here.
+func (w *GameWorld) disconnectSpectator(s *Session) bool {
+	w.spmu.Lock()
+	defer w.spmu.Unlock()
+	if _, ok := w.Spectators[s]; !ok {
+		return false
+	}
+	delete(w.Spectators, s)
+	return true
+}
+
+// Follow
+// Only sends a spectator moves near who, empty who is the whole world.
+func (w *GameWorld) Follow(s *Session, who string) error {
+	var target *Session
+	name := ""
+	if who != "" {
+		target, name = w.findLocalSession(who)
+		if target == nil {
+			return fmt.Errorf("%w: %s", ErrFollowMissing, who)
+		}
+	}
+
+	w.spmu.Lock()
+	sp, ok := w.Spectators[s]
+	if ok {
+		sp.Follow = name
+		sp.target = target
+	}
+	w.spmu.Unlock()
+	if !ok {
+		return ErrNotSpectator
+	}
+
+	if target == nil {
+		w.sendNotice(s, cpnp.NoticeKind_info, "Watching the whole world")
+	} else {
+		w.sendNotice(s, cpnp.NoticeKind_info, fmt.Sprintf("Following %s", name))
+	}
+	return nil
+}
+
+func (w *GameWorld) HandleClientSpectate(s *Session, payload []byte) {
+	msg, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientSpectate)
+	if !valid {
+		return
+	}
+	who, err := msg.Follow()
+	if err != nil {
+		return
+	}
+	err = w.Follow(s, who)
+	if err != nil {
+		w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Can't follow %q: %v", who, err))
+	}
+}
+
+// spectatorSessions
+// Every spectator in the world.
+func (w *GameWorld) spectatorSessions() []*Session {
+	w.spmu.RLock()
+	defer w.spmu.RUnlock()
+	to := make([]*Session, 0, len(w.Spectators))
+	for s := range w.Spectators {
+		to = append(to, s)
+	}
+	return to
+}
+
+// spectatorsSeeing
+// Spectators that should get a move to x, y.
+// Ones following someone only see moves within SpectatorView of them.
+func (w *GameWorld) spectatorsSeeing(x, y int) []*Session {
+	type watcher struct {
+		s, target *Session
+	}
+	w.spmu.RLock()
+	watchers := make([]watcher, 0, len(w.Spectators))
+	for s, sp := range w.Spectators {
+		watchers = append(watchers, watcher{s, sp.target})
+	}
+	w.spmu.RUnlock()
+
+	var to []*Session
+	for _, wt := range watchers {
+		if wt.target == nil {
+			to = append(to, wt.s)
+			continue
+		}
+		w.pmu.RLock()
+		p, ok := w.Players[wt.target]
+		w.pmu.RUnlock()
+		if !ok {
+			// Followed player left, show everything.
+			to = append(to, wt.s)
+			continue
+		}
+		p.mu.Lock()
+		px, py := p.X, p.Y
+		p.mu.Unlock()
+		if w.Map.Distance(px, py, x, y) <= w.config.SpectatorView {
+			to = append(to, wt.s)
+		}
+	}
+	return to
+}
+
+// broadcastPlayers
+// Broadcast without spectators.
+func (w *GameWorld) broadcastPlayers(msg *capnp.Message, opcode uint16) {
+	w.pmu.RLock()
+	defer w.pmu.RUnlock()
+	for s := range w.Players {
+		_, _ = s.Send(w.writer, msg, opcode)
+	}
+}
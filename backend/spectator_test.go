@@ -0,0 +1,65 @@
+package backend
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestSpectator(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.MapWidth, c.MapHeight = 50, 50
+		c.SpectatorView = 5
+	})
+	worlds, w := g.worlds, g.w
+
+	player := g.join(t, "")
+	spec := testSession(t, g.db, g.sessions)
+	spec.Spectator = true
+	spec.Follow = "nobody"
+	if err := worlds.Connect(spec, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := w.Players[spec]; ok {
+		t.Fatal("spectator got a player")
+	}
+	if _, ok := spec.handlers[OpCodeCMoved]; ok {
+		t.Error("spectator can move")
+	}
+	if _, ok := spec.handlers[OpCodeCChat]; ok {
+		t.Error("spectator can chat")
+	}
+	// Unknown follow falls back to the whole world.
+	if !slices.Contains(w.spectatorSessions(), spec) {
+		t.Fatal("not spectating")
+	}
+	if !slices.Contains(w.spectatorsSeeing(0, 0), spec) {
+		t.Error("unfollowing spectator should see everything")
+	}
+
+	if err := w.Follow(spec, "nobody"); !errors.Is(err, ErrFollowMissing) {
+		t.Errorf("expected missing, got %v", err)
+	}
+	if err := w.Follow(player, ""); !errors.Is(err, ErrNotSpectator) {
+		t.Errorf("expected not spectator, got %v", err)
+	}
+	if err := w.Follow(spec, player.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	p := w.Players[player]
+	if err := w.Map.Place(p, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Contains(w.spectatorsSeeing(14, 10), spec) {
+		t.Error("should see moves near followed player")
+	}
+	if slices.Contains(w.spectatorsSeeing(30, 30), spec) {
+		t.Error("should not see moves far from followed player")
+	}
+
+	worlds.Disconnect(spec)
+	if len(w.spectatorSessions()) != 0 {
+		t.Error("spectator still there after disconnect")
+	}
+}
@@ -16,24 +16,28 @@ import (
 
 const ErrSessionStreamClosed webtransport.StreamErrorCode = 3000
 
+// LoginSecretHeader
+// Login response header with a new user's secret, send it back as secret to log in as them again.
+const LoginSecretHeader = "Login-Secret"
+
 type WebTransportServer struct {
 	db *DatabaseManager
 
-	world    *GameWorld
+	worlds   *WorldManager
 	sessions *SessionManager
 
 	wt  *webtransport.Server
 	udp *net.UDPConn
 }
 
-func NewWebTransportServer() *WebTransportServer {
+func NewWebTransportServer(config GameConfig) *WebTransportServer {
 	db := NewDatabaseManager()
-	world := NewGameWorld(db)
+	worlds := NewWorldManager(db, config)
 
-	go world.Start()
+	worlds.Start()
 
 	return &WebTransportServer{
-		world:    world,
+		worlds:   worlds,
 		db:       db,
 		sessions: NewSessionManager(),
 	}
@@ -94,7 +98,9 @@ func (s *WebTransportServer) Start() bool {
 		}
 	}()
 
-	go s.sessions.Run(s.world)
+	go s.sessions.Run(s.worlds)
+	sampler := &loadSampler{sessions: s.sessions, worlds: s.worlds}
+	go s.worlds.Garbage.Run(sampler.Sample, s.worlds.closing)
 
 	return true
 }
@@ -123,6 +129,11 @@ func (s *WebTransportServer) Stop() {
 		s.udp = nil
 	}
 
+	if s.worlds != nil {
+		s.worlds.Shutdown()
+		s.worlds = nil
+	}
+
 	log.Println("Stopped WebTransportServer")
 }
 
@@ -140,12 +151,46 @@ func (s *WebTransportServer) HandleLogin(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	code, err := s.db.Login(name)
+	// Optional world to join
+	world := query.Get("world")
+	if _, ok := s.worlds.Get(world); world != "" && !ok {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		log.Printf("Bad Request unknown world %q\n", world)
+		return
+	}
+
+	login := LoginOptions{
+		World:    world,
+		Spectate: query.Get("spectate") == "true" || query.Get("spectate") == "1",
+		Follow:   query.Get("follow"),
+
+		GarbageHashes:    ParseGarbageHashes(query.Get("hashes")),
+		GarbageEncodings: ParseGarbageEncodings(query.Get("encodings")),
+		GarbageModes:     ParseGarbageModes(query.Get("modes")),
+	}
+	// A new name gets a secret back, logging in as it again needs it.
+	uid, secret, err := s.db.LoginUser(name, query.Get("secret"))
+	if errors.Is(err, ErrLoginSecret) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		log.Printf("Forbidden name %q: %v\n", name, err)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		log.Printf("Bad Request name %q: %v\n", name, err)
+		return
+	}
+	// No check for being online, the secret proves it's them.
+	// A client that dropped without the server noticing takes its session back in handleWT.
+	code, err := s.db.NewTransportLogin(uid, login)
 	if err != nil {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		log.Printf("Bad Request %s\n", r.URL.Path)
 		return
 	}
+	if secret != "" {
+		w.Header().Set(LoginSecretHeader, secret)
+	}
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	_, err = w.Write([]byte(code.String()))
 	if err != nil {
@@ -155,14 +200,14 @@ func (s *WebTransportServer) HandleLogin(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-func (s *WebTransportServer) verifyWT(w http.ResponseWriter, r *http.Request) (bool, uuid.UUID) {
+func (s *WebTransportServer) verifyWT(w http.ResponseWriter, r *http.Request) (bool, uuid.UUID, LoginOptions) {
 	query := r.URL.Query()
 
 	// Check for code
 	if !query.Has("code") {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		log.Printf("Bad Request %s\n", r.URL.Path)
-		return false, uuid.Nil
+		return false, uuid.Nil, LoginOptions{}
 	}
 
 	// Code not empty
@@ -170,7 +215,7 @@ func (s *WebTransportServer) verifyWT(w http.ResponseWriter, r *http.Request) (b
 	if code == "" {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		log.Printf("Bad Request %s\n", r.URL.Path)
-		return false, uuid.Nil
+		return false, uuid.Nil, LoginOptions{}
 	}
 
 	// Actual UUID
@@ -178,25 +223,43 @@ func (s *WebTransportServer) verifyWT(w http.ResponseWriter, r *http.Request) (b
 	if id == uuid.Nil {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		log.Printf("Bad Request %s\n", id)
-		return false, uuid.Nil
+		return false, uuid.Nil, LoginOptions{}
 	}
 
-	uid, err := s.db.VerifyTransport(id)
+	uid, login, err := s.db.VerifyTransportLogin(id)
 	if err != nil || uid == uuid.Nil {
 		http.Error(w, "Bad Request", http.StatusBadRequest)
 		log.Printf("Bad Request Not verified %s\n", id)
-		return false, uuid.Nil
+		return false, uuid.Nil, LoginOptions{}
 	}
 
-	return true, uid
+	// The wt request can also pick the world
+	if query.Has("world") {
+		login.World = query.Get("world")
+	}
+	if _, ok := s.worlds.Get(login.World); login.World != "" && !ok {
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+		log.Printf("Bad Request unknown world %q\n", login.World)
+		return false, uuid.Nil, LoginOptions{}
+	}
+
+	return true, uid, login
 }
 
 func (s *WebTransportServer) handleWT() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		ok, uid := s.verifyWT(w, r)
+		ok, uid, login := s.verifyWT(w, r)
 		if !ok {
 			return
 		}
+		clientIP, _, _ := net.SplitHostPort(r.RemoteAddr)
+		existing, lookupErr := s.sessions.GetValidSession(uid, clientIP)
+		if errors.Is(lookupErr, ErrSessionIPMismatch) {
+			// Still connected from somewhere else, try again once that one times out.
+			http.Error(w, ErrNameTaken.Error(), http.StatusConflict)
+			log.Printf("Conflict user %s online from another address\n", uid)
+			return
+		}
 		log.Printf("Starting wt request from %s user %s", r.RemoteAddr, uid.String())
 		sess, err := s.wt.Upgrade(w, r)
 		if err != nil {
@@ -207,23 +270,31 @@ func (s *WebTransportServer) handleWT() http.HandlerFunc {
 		w.WriteHeader(http.StatusOK)
 
 		var session *Session
-		clientIP, _, _ := net.SplitHostPort(r.RemoteAddr)
-		existing, err := s.sessions.GetValidSession(uid, clientIP)
-		if err == nil {
+		if lookupErr == nil {
 			// s.sessions.
 			log.Printf("Reconnecting session %s from %s\n", uid, clientIP)
 			session = existing
-			// TODO: Test reconnect
 			// This doesn't reset the world connection.
 			err = session.Reconnect(sess)
-			s.world.Reconnect(session)
+			s.worlds.Reconnect(session)
 		}
 
 		if session == nil {
 			log.Printf("Creating new session for %s from %s\n", uid, clientIP)
 			session = s.sessions.CreateSession(uid, clientIP, sess)
+			session.Spectator = login.Spectate
+			session.Follow = login.Follow
+			session.GarbageHashes = login.GarbageHashes
+			session.GarbageEncodings = login.GarbageEncodings
+			session.GarbageModes = login.GarbageModes
 			err = session.Start()
-			s.world.Connect(session)
+			if err == nil {
+				err = s.worlds.Connect(session, login.World)
+				if err != nil {
+					// Nowhere to put them, don't leave the session open.
+					_ = session.Close()
+				}
+			}
 		}
 
 		if err != nil {
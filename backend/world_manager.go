@@ -0,0 +1,391 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/gofrs/uuid/v5"
+
+	"simpleWT/backend/cpnp"
+)
+
+var (
+	ErrWorldNotFound = errors.New("world not found")
+	ErrWorldExists   = errors.New("world already exists")
+	ErrWorldSame     = errors.New("already in world")
+)
+
+// WorldManager
+// Hosts all the worlds and keeps track of which one each session is in.
+type WorldManager struct {
+	worlds map[string]*GameWorld
+	wmu    sync.RWMutex
+
+	// Which world a session is in
+	sessions map[*Session]*GameWorld
+	smu      sync.RWMutex
+
+	Default string
+
+	chat *ChatManager
+
+	// Garbage scales new challenges with load.
+	Garbage *GarbageController
+	// garbageUsers GarbageUsers by user ID, filled in at startup and only read after.
+	garbageUsers map[uuid.UUID]GarbagePolicy
+
+	events *EventLog
+
+	// Where players left off, from Disconnect or a snapshot.
+	resume map[uuid.UUID]PlayerSnapshot
+	rmu    sync.Mutex
+	// From a snapshot, used when the world gets created.
+	seeds map[string]uint64
+	chats map[string][]ChatSnapshot
+
+	// Rename limits per user.
+	renames renameLimits
+	remu    sync.Mutex
+
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	db     *DatabaseManager
+	config GameConfig
+}
+
+// NewWorldManager
+// Creates the default world and any from the config.
+// Worlds aren't ticking until Start.
+func NewWorldManager(db *DatabaseManager, config GameConfig) *WorldManager {
+	m := &WorldManager{
+		worlds:   make(map[string]*GameWorld),
+		sessions: make(map[*Session]*GameWorld),
+		Default:  config.DefaultWorld,
+		chat:     NewChatManager(config.Chat),
+		Garbage:  NewGarbageController(config.AdaptiveGarbage),
+		db:       db,
+		config:   config,
+
+		resume:  make(map[uuid.UUID]PlayerSnapshot),
+		seeds:   make(map[string]uint64),
+		chats:   make(map[string][]ChatSnapshot),
+		closing: make(chan struct{}),
+
+		renames: renameLimits{limit: config.Rename, buckets: make(map[uuid.UUID]*tokenBucket)},
+	}
+	if m.Default == "" {
+		m.Default = DefaultWorldName
+	}
+	if config.EventLog != "" {
+		events, err := OpenEventLog(config.EventLog)
+		if err != nil {
+			log.Printf("Error opening event log: %v\n", err)
+		}
+		m.events = events
+	}
+	if config.Snapshot.Path != "" {
+		snap, err := LoadSnapshot(config.Snapshot.Path)
+		if err == nil {
+			m.restore(snap)
+			log.Printf("Loaded snapshot from %s, saved %s\n", config.Snapshot.Path, snap.Saved)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Error loading snapshot: %v\n", err)
+		}
+	}
+
+	m.reserveModerators(config.Chat.Moderators)
+	m.reserveGarbageUsers(config.GarbageUsers)
+
+	_, _ = m.create(WorldConfig{Name: m.Default})
+	for _, wc := range config.Worlds {
+		_, err := m.create(wc)
+		if err != nil {
+			log.Printf("Error creating world %s: %v\n", wc.Name, err)
+		}
+	}
+	return m
+}
+
+// reserveModerators
+// Makes users for the moderator names before anyone else can log in as them.
+func (m *WorldManager) reserveModerators(names []string) {
+	for _, name := range names {
+		uid, err := m.reserveUser(name)
+		if err != nil {
+			log.Printf("Error reserving moderator %q: %v\n", name, err)
+			continue
+		}
+		m.chat.AddModerator(uid)
+	}
+}
+
+// reserveGarbageUsers
+// Same as moderators, so nobody else can take a name to get its policy.
+func (m *WorldManager) reserveGarbageUsers(users map[string]GarbagePolicy) {
+	m.garbageUsers = make(map[uuid.UUID]GarbagePolicy, len(users))
+	for name, g := range users {
+		uid, err := m.reserveUser(name)
+		if err != nil {
+			log.Printf("Error reserving garbage user %q: %v\n", name, err)
+			continue
+		}
+		m.garbageUsers[uid] = g
+	}
+}
+
+// reserveUser
+// Makes a user for a name from the config, a new one's secret goes to ReservedSecrets.
+// Secrets never go in the log.
+func (m *WorldManager) reserveUser(name string) (uuid.UUID, error) {
+	uid, secret, err := m.db.ReserveUser(name)
+	if err != nil || secret == "" {
+		return uid, err
+	}
+	if m.config.ReservedSecrets == "" {
+		log.Printf("Reserved %s, no secrets file so it can't be logged in to\n", name)
+		return uid, nil
+	}
+	err = appendSecret(m.config.ReservedSecrets, name, secret)
+	if err != nil {
+		log.Printf("Error saving login secret for %s: %v\n", name, err)
+		return uid, nil
+	}
+	log.Printf("Reserved %s, login secret saved to %s\n", name, m.config.ReservedSecrets)
+	return uid, nil
+}
+
+// appendSecret
+// Adds a name and secret line to a file only the owner can read.
+func appendSecret(path, name, secret string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	// Already there from before, tighten it anyway.
+	err = f.Chmod(0o600)
+	if err == nil {
+		_, err = fmt.Fprintf(f, "%s\t%s\n", name, secret)
+	}
+	return errors.Join(err, f.Close())
+}
+
+func (m *WorldManager) create(wc WorldConfig) (*GameWorld, error) {
+	if wc.Name == "" {
+		return nil, fmt.Errorf("%w: no name", ErrWorldNotFound)
+	}
+	m.wmu.Lock()
+	defer m.wmu.Unlock()
+	if _, ok := m.worlds[wc.Name]; ok {
+		return nil, ErrWorldExists
+	}
+	if seed, ok := m.seeds[wc.Name]; ok && wc.Seed == 0 {
+		// Same walls as before the restart.
+		wc.Seed = seed
+	}
+	w := newGameWorld(wc.Name, m.db, m.config.World(wc), m.events)
+	w.manager = m
+	restoreChat(w.history, m.chats[wc.Name])
+	delete(m.chats, wc.Name)
+	m.worlds[wc.Name] = w
+	return w, nil
+}
+
+// Create
+// Adds a world while running and starts it.
+func (m *WorldManager) Create(wc WorldConfig) (*GameWorld, error) {
+	w, err := m.create(wc)
+	if err != nil {
+		return nil, err
+	}
+	go w.Start()
+	return w, nil
+}
+
+// Start
+// Starts every world's tick.
+func (m *WorldManager) Start() {
+	m.wmu.RLock()
+	defer m.wmu.RUnlock()
+	for _, w := range m.worlds {
+		go w.Start()
+	}
+	go m.runSnapshots()
+}
+
+// Shutdown
+// Stops every world.
+func (m *WorldManager) Shutdown() {
+	m.closeOnce.Do(func() { close(m.closing) })
+	err := m.SaveSnapshot()
+	if err != nil {
+		log.Printf("Error saving snapshot: %v\n", err)
+	}
+
+	m.wmu.Lock()
+	defer m.wmu.Unlock()
+	for name, w := range m.worlds {
+		w.Shutdown()
+		delete(m.worlds, name)
+	}
+	err = m.events.Close()
+	if err != nil {
+		log.Printf("Error closing event log: %v\n", err)
+	}
+}
+
+// Get
+// A world by name.
+func (m *WorldManager) Get(name string) (*GameWorld, bool) {
+	m.wmu.RLock()
+	defer m.wmu.RUnlock()
+	w, ok := m.worlds[name]
+	return w, ok
+}
+
+// Names
+// Sorted names of all worlds.
+func (m *WorldManager) Names() []string {
+	m.wmu.RLock()
+	defer m.wmu.RUnlock()
+	names := make([]string, 0, len(m.worlds))
+	for name := range m.worlds {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WorldOf
+// The world a session is in, nil if none.
+func (m *WorldManager) WorldOf(s *Session) *GameWorld {
+	m.smu.RLock()
+	defer m.smu.RUnlock()
+	return m.sessions[s]
+}
+
+// Connect
+// Puts a new session in a world, empty name is the default world.
+func (m *WorldManager) Connect(s *Session, name string) error {
+	if name == "" && !s.Spectator {
+		name = m.resumeWorld(s.ID)
+	}
+	if name == "" {
+		name = m.Default
+	}
+	w, ok := m.Get(name)
+	if !ok {
+		return ErrWorldNotFound
+	}
+
+	s.AddHandler(OpCodeCChangeWorld, m.HandleChangeWorld)
+	s.AddHandler(OpCodeCRename, m.HandleRename)
+
+	m.smu.Lock()
+	m.sessions[s] = w
+	m.smu.Unlock()
+
+	err := w.Connect(s)
+	if err != nil {
+		m.smu.Lock()
+		delete(m.sessions, s)
+		m.smu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Reconnect
+// Session came back, put it back where it was.
+func (m *WorldManager) Reconnect(s *Session) {
+	w := m.WorldOf(s)
+	if w == nil {
+		err := m.Connect(s, "")
+		if err != nil {
+			log.Printf("Error reconnecting %s: %v\n", s.ID, err)
+			_ = s.Close()
+		}
+		return
+	}
+	w.Reconnect(s)
+}
+
+// Disconnect
+// Session is gone for good.
+func (m *WorldManager) Disconnect(s *Session) {
+	m.smu.Lock()
+	w, ok := m.sessions[s]
+	delete(m.sessions, s)
+	m.smu.Unlock()
+	m.chat.Leave(s)
+	m.chat.Forget(s)
+	if !ok {
+		return
+	}
+	m.remember(w, s)
+	w.Disconnect(s)
+}
+
+// ChangeWorld
+// Moves a session to another world without dropping the connection.
+// The old world sees a disconnect and the new one a connect, the inventory comes along.
+// If the new world has no room the session stays where it was.
+func (m *WorldManager) ChangeWorld(s *Session, name string) error {
+	to, ok := m.Get(name)
+	if !ok {
+		return ErrWorldNotFound
+	}
+
+	m.smu.Lock()
+	from := m.sessions[s]
+	if from == to {
+		m.smu.Unlock()
+		return ErrWorldSame
+	}
+	m.sessions[s] = to
+	m.smu.Unlock()
+
+	// Placed in the new world before leaving the old one, so a failed spawn loses nothing.
+	if from != nil {
+		m.remember(from, s)
+	}
+	log.Printf("Session %s changing world to %s\n", s.ID, name)
+	err := to.Connect(s)
+	if err != nil {
+		m.smu.Lock()
+		if from != nil {
+			m.sessions[s] = from
+		} else {
+			delete(m.sessions, s)
+		}
+		m.smu.Unlock()
+		return err
+	}
+	if from != nil {
+		from.Disconnect(s)
+	}
+	return nil
+}
+
+func (m *WorldManager) HandleChangeWorld(s *Session, payload []byte) {
+	msg, valid := DeserializeValid(s.reader, payload, cpnp.ReadRootGameClientChangeWorld)
+	if !valid {
+		return
+	}
+	name, err := msg.Name()
+	if err != nil {
+		return
+	}
+
+	err = m.ChangeWorld(s, name)
+	if err != nil {
+		w := m.WorldOf(s)
+		if w != nil {
+			w.sendNotice(s, cpnp.NoticeKind_warning, fmt.Sprintf("Can't change world to %q: %v", name, err))
+		}
+	}
+}
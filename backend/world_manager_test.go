@@ -0,0 +1,179 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-faker/faker/v4"
+)
+
+// testStream
+// Stands in for the session stream, keeps what was sent.
+type testStream struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (t *testStream) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.buf.Write(p)
+}
+
+// testStart
+// Marks a session started with a testStream instead of a connection.
+func testStart(s *Session) *Session {
+	s.out = new(testStream)
+	s.Active.Store(true)
+	return s
+}
+
+// testSession
+// A session with no connection, sends go to a testStream.
+func testSession(tb testing.TB, db *DatabaseManager, sessions *SessionManager) *Session {
+	uid, err := db.GetUser(faker.Name())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return testStart(sessions.CreateSession(uid, "127.0.0.1", nil))
+}
+
+// testGame
+// Fresh database, worlds and sessions, w is the default world.
+type testGame struct {
+	db       *DatabaseManager
+	worlds   *WorldManager
+	sessions *SessionManager
+	w        *GameWorld
+}
+
+// newTestGame
+// edit changes the default config first, nil leaves it as is.
+func newTestGame(tb testing.TB, edit func(*GameConfig)) *testGame {
+	config := DefaultGameConfig()
+	if edit != nil {
+		edit(&config)
+	}
+	g := &testGame{db: NewDatabaseManager(), sessions: NewSessionManager()}
+	g.worlds = NewWorldManager(g.db, config)
+	w, ok := g.worlds.Get(DefaultWorldName)
+	if !ok {
+		tb.Fatal("no default world")
+	}
+	g.w = w
+	return g
+}
+
+// join
+// A new testSession connected to a world, empty for the default.
+func (g *testGame) join(tb testing.TB, world string) *Session {
+	s := testSession(tb, g.db, g.sessions)
+	if err := g.worlds.Connect(s, world); err != nil {
+		tb.Fatal(err)
+	}
+	return s
+}
+
+func TestChangeWorld(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Worlds = []WorldConfig{{Name: "other", MapWidth: 10, MapHeight: 10}}
+	})
+	worlds := g.worlds
+
+	s := testSession(t, g.db, g.sessions)
+	if err := worlds.Connect(s, "missing"); !errors.Is(err, ErrWorldNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if err := worlds.Connect(s, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	main, _ := worlds.Get(DefaultWorldName)
+	other, _ := worlds.Get("other")
+	if worlds.WorldOf(s) != main {
+		t.Fatal("not in default world")
+	}
+
+	if err := worlds.ChangeWorld(s, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := worlds.ChangeWorld(s, "other"); !errors.Is(err, ErrWorldSame) {
+		t.Fatalf("expected same world, got %v", err)
+	}
+	if worlds.WorldOf(s) != other {
+		t.Fatal("not moved to other world")
+	}
+	if _, ok := main.Players[s]; ok {
+		t.Error("still in old world")
+	}
+	p, ok := other.Players[s]
+	if !ok {
+		t.Fatal("not in new world")
+	}
+	if p.X >= 10 || p.Y >= 10 {
+		t.Errorf("spawned off the map %d,%d", p.X, p.Y)
+	}
+
+	worlds.Disconnect(s)
+	if _, ok := other.Players[s]; ok {
+		t.Error("still in world after disconnect")
+	}
+}
+
+func TestChangeWorldInventory(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Worlds = []WorldConfig{{Name: "other"}}
+	})
+	other, _ := g.worlds.Get("other")
+
+	s := g.join(t, "")
+	g.w.Players[s].Inventory = Inventory{"coin": 2}
+
+	if err := g.worlds.ChangeWorld(s, "other"); err != nil {
+		t.Fatal(err)
+	}
+	p := other.Players[s]
+	if p.Inventory["coin"] != 2 {
+		t.Fatalf("inventory in other world %v", p.Inventory)
+	}
+	p.Inventory["gem"] = 1
+
+	if err := g.worlds.ChangeWorld(s, DefaultWorldName); err != nil {
+		t.Fatal(err)
+	}
+	if inv := g.w.Players[s].Inventory; inv["coin"] != 2 || inv["gem"] != 1 {
+		t.Errorf("inventory back in the first world %v", inv)
+	}
+
+	// Resume has it after leaving too.
+	g.worlds.Disconnect(s)
+	s = testStart(g.sessions.CreateSession(s.ID, "127.0.0.1", nil))
+	if err := g.worlds.Connect(s, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if inv := other.Players[s].Inventory; inv["coin"] != 2 || inv["gem"] != 1 {
+		t.Errorf("inventory after resume %v", inv)
+	}
+}
+
+func TestChangeWorldFull(t *testing.T) {
+	g := newTestGame(t, func(c *GameConfig) {
+		c.Collision = true
+		c.Walls = 0
+		c.Worlds = []WorldConfig{{Name: "full", MapWidth: 1, MapHeight: 1}}
+	})
+	g.join(t, "full")
+
+	s := g.join(t, "")
+	if err := g.worlds.ChangeWorld(s, "full"); !errors.Is(err, ErrMapFull) {
+		t.Fatalf("expected map full, got %v", err)
+	}
+	if g.worlds.WorldOf(s) != g.w || g.w.Players[s] == nil {
+		t.Error("not left in the old world")
+	}
+	if !s.Active.Load() {
+		t.Error("session closed")
+	}
+}
@@ -19,15 +19,41 @@ func main() {
 
 	// Simple Client flag
 	cPtr := flag.Int("c", 1, "clients")
+	movePtr := flag.Int("move", 0, "random moves per second per client")
+	latPtr := flag.Duration("latency", 0, "simulated latency on moves")
+	worldPtr := flag.String("world", "", "world to join")
+	gotoPtr := flag.Bool("goto", false, "walk to random cells with server path finding")
+	specPtr := flag.Bool("spectate", false, "watch without playing")
+	followPtr := flag.String("follow", "", "player a spectator follows")
+	reconnectPtr := flag.Int("reconnect", 0, "reconnect attempts after the connection drops, 0 never")
+	resyncPtr := flag.Duration("resync", 0, "how often to check the client's world against the server, 0 never")
 	flag.Parse()
 
+	reconnect := backend.DefaultReconnectPolicy()
+	reconnect.Attempts = *reconnectPtr
+
 	var clients []*backend.Client
 	if *cPtr > 0 {
 		for i := range *cPtr {
 			go func() {
-				c := connectClient(i)
+				c := connectClient(i, backend.ClientConnection{
+					World:     *worldPtr,
+					Latency:   *latPtr,
+					Spectate:  *specPtr,
+					Follow:    *followPtr,
+					Reconnect: reconnect,
+				})
 				if c != nil {
 					clients = append(clients, c)
+					if *specPtr {
+						return
+					}
+					go resync(c, *resyncPtr)
+					if *gotoPtr {
+						go roam(c)
+					} else {
+						go wander(c, *movePtr)
+					}
 				}
 			}()
 		}
@@ -38,18 +64,89 @@ func main() {
 	<-signalChan
 
 	for _, client := range clients {
+		gs := client.GarbageStats()
+		log.Printf("Client %s: garbage %d batches sent, %d acked, %d ticks stalled on the window\n",
+			client.Name, gs.Sent, gs.Acked, gs.Stalls)
+		st := client.PredictionStats()
+		if st.Acks > 0 {
+			log.Printf("Client %s: %d acks, %d mispredicted, avg error %.2f, max %d\n",
+				client.Name, st.Acks, st.Mispredicted, float64(st.TotalError)/float64(st.Acks), st.MaxError)
+		}
+		ms := client.Mirror().Stats()
+		if ms.Checks > 0 {
+			log.Printf("Client %s: world checked %d times, %d drifted\n", client.Name, ms.Checks, ms.Drifted)
+		}
 		client.Close()
 	}
 }
 
-func connectClient(n int) *backend.Client {
+// resync
+// Asks for the player list every so often so the mirror gets checked.
+func resync(c *backend.Client, every time.Duration) {
+	if every <= 0 {
+		return
+	}
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Closing:
+			return
+		case <-ticker.C:
+			_ = c.Resync()
+		}
+	}
+}
+
+// wander
+// Random walk to test movement prediction.
+func wander(c *backend.Client, perSecond int) {
+	if perSecond <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Second / time.Duration(perSecond))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Closing:
+			return
+		case <-ticker.C:
+			_ = c.Move(int8(rand.IntN(3)-1), int8(rand.IntN(3)-1))
+		}
+	}
+}
+
+// roam
+// Picks a random cell, waits to get there, repeat.
+func roam(c *backend.Client) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Closing:
+			return
+		case <-ticker.C:
+			w, h := c.MapSize()
+			if w == 0 || c.Pathing() {
+				continue
+			}
+			_ = c.MoveTo(rand.IntN(w), rand.IntN(h))
+		}
+	}
+}
+
+func connectClient(n int, cc backend.ClientConnection) *backend.Client {
 	sran := rand.IntN(5)
 	time.Sleep(time.Duration(sran) * time.Second)
-	name := fmt.Sprintf("%s-%d", faker.Name(), n)
-	log.Printf("Client: Connecting as: %s\n", name)
-	c, err := backend.ClientConnect(backend.ClientConnection{Name: name})
-	if err == nil {
-		return c
+	cc.Name = fmt.Sprintf("%s-%d", faker.Name(), n)
+	log.Printf("Client: Connecting as: %s\n", cc.Name)
+	c, err := backend.ClientConnect(cc)
+	if err != nil {
+		log.Printf("Client: %s failed to connect: %v\n", cc.Name, err)
+		return nil
 	}
-	return nil
+	backend.OnEvent(c, func(ev backend.StateChanged) {
+		log.Printf("Client %s: %s -> %s\n", c.Name, ev.From, ev.To)
+	})
+	return c
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"simpleWT/backend"
+)
+
+func main() {
+
+	// Rebuilds a world from the server's event log.
+
+	logPtr := flag.String("log", "events.log", "event log to read")
+	worldPtr := flag.String("world", backend.DefaultWorldName, "world to rebuild")
+	tickPtr := flag.Uint64("tick", 0, "stop after this tick, 0 for the whole log")
+	chatPtr := flag.Int("chat", 10, "chat messages to show")
+	flag.Parse()
+
+	f, err := os.Open(*logPtr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	state, err := backend.Replay(f, *worldPtr, *tickPtr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("World %s seed %d, %dx%d, %d walls\n", state.World, state.Seed, state.Map.Width, state.Map.Height, len(state.Map.Walls()))
+	fmt.Printf("Tick %d after %d events\n", state.Tick, state.Events)
+
+	var online []*backend.ReplayEntity
+	for _, ent := range state.Entities {
+		if ent.Online {
+			online = append(online, ent)
+		}
+	}
+	slices.SortFunc(online, func(a, b *backend.ReplayEntity) int { return strings.Compare(a.Name, b.Name) })
+	fmt.Printf("%d online, %d seen\n", len(online), len(state.Entities))
+	for _, ent := range online {
+		fmt.Printf("  %s (%s) at %d,%d\n", ent.Name, ent.ID, ent.X, ent.Y)
+	}
+
+	fmt.Printf("%d items on the map\n", len(state.Items))
+	fmt.Printf("Garbage %d passed, %d failed\n", state.GarbagePassed, state.GarbageFailed)
+
+	chat := state.Chat[max(len(state.Chat)-*chatPtr, 0):]
+	fmt.Printf("Last %d of %d chat messages\n", len(chat), len(state.Chat))
+	for _, e := range chat {
+		if e.Channel == "whisper" {
+			fmt.Printf("  [%d %s] %s -> %s\n", e.Tick, e.Channel, e.Name, e.Target)
+			continue
+		}
+		fmt.Printf("  [%d %s] %s: %s\n", e.Tick, e.Channel, e.Name, e.Text)
+	}
+
+	if len(state.Problems) > 0 {
+		fmt.Printf("%d problems\n", len(state.Problems))
+		for _, p := range state.Problems {
+			fmt.Printf("  %s\n", p)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -24,13 +25,93 @@ func main() {
 	// Starts a server and can make clients.
 
 	cPtr := flag.Int("c", 0, "clients")
+	collision := flag.Bool("collision", false, "one player per cell")
+	config := backend.DefaultGameConfig()
+	moveRate := flag.Float64("move-rate", config.Movement.Limit.Rate, "max steps per second, 0 for no limit")
+	moveBurst := flag.Int("move-burst", config.Movement.Limit.Burst, "steps that can be saved up")
+	walls := flag.Int("walls", 0, "random walls per map")
+	worlds := flag.String("worlds", "", "comma separated extra worlds to host")
+	npcs := flag.Int("npcs", 0, "NPCs per world")
+	events := flag.String("events", "", "file to append world events to")
+	seed := flag.Uint64("seed", 0, "map seed, 0 for random")
+	snapshot := flag.String("snapshot", "", "file to save world state to and load it from")
+	snapshotEvery := flag.Duration("snapshot-every", config.Snapshot.Every, "how often to save the snapshot")
+	mods := flag.String("mods", "", "comma separated names allowed to /mute, reserved at startup")
+	reservedSecrets := flag.String("reserved-secrets", "reserved.secrets", "file new reserved names' login secrets are appended to, readable only by you")
+	garbageAmount := flag.String("garbage-amount", config.Garbage.Amount.String(), "hashes per garbage message, n or min-max, 0 turns garbage off")
+	garbageRate := flag.String("garbage-rate", config.Garbage.Rate.String(), "garbage messages per second, n or min-max")
+	garbageDuration := flag.String("garbage-duration", config.Garbage.Duration.String(), "seconds per garbage challenge, n or min-max")
+	garbageCooldown := flag.Duration("garbage-cooldown", config.Garbage.Cooldown, "wait between garbage challenges")
+	garbageHashes := flag.String("garbage-hashes", "", "comma separated garbage hashes to ask for, best first: sha1, sha256, sha512, sha3, blake2b, empty for all")
+	garbageWindow := flag.Int("garbage-window", config.Garbage.Window, "garbage batches a client can have unacked, 1 is stop and wait")
+	garbageMode := flag.String("garbage-mode", "recompute", "recompute, or work to make clients find nonces")
+	garbageDifficulty := flag.String("garbage-difficulty", "12-16", "leading zero bits per nonce in work mode, n or min-max, at most 24")
+	garbageEncodings := flag.String("garbage-encodings", "", "comma separated garbage encodings to ask for, best first: text, hex, binary, empty for all")
+	garbageAdapt := flag.Duration("garbage-adapt", config.AdaptiveGarbage.Every, "how often to scale garbage with load, 0 turns it off")
+	garbageMinScale := flag.Float64("garbage-min-scale", config.AdaptiveGarbage.MinScale, "lowest garbage scale under load, at least 0.01")
+	garbageMaxScale := flag.Float64("garbage-max-scale", config.AdaptiveGarbage.MaxScale, "highest garbage scale when calm, above 1 asks for more than the policy")
 	flag.Parse()
 
+	config.Collision = *collision
+	config.Movement.Limit = backend.RateLimit{Rate: *moveRate, Burst: *moveBurst}
+	config.Walls = *walls
+	config.NPC.Count = *npcs
+	config.EventLog = *events
+	config.ReservedSecrets = *reservedSecrets
+	config.Seed = *seed
+	config.Snapshot = backend.SnapshotConfig{Path: *snapshot, Every: *snapshotEvery}
+	for _, r := range []struct {
+		flag string
+		to   *backend.Range
+	}{
+		{*garbageAmount, &config.Garbage.Amount},
+		{*garbageRate, &config.Garbage.Rate},
+		{*garbageDuration, &config.Garbage.Duration},
+		{*garbageDifficulty, &config.Garbage.Difficulty},
+	} {
+		parsed, err := backend.ParseRange(r.flag)
+		if err != nil {
+			log.Fatalf("Error parsing garbage flags: %v\n", err)
+		}
+		*r.to = parsed
+	}
+	config.Garbage.Cooldown = *garbageCooldown
+	config.Garbage.Window = *garbageWindow
+	config.AdaptiveGarbage.Every = *garbageAdapt
+	config.AdaptiveGarbage.MinScale = *garbageMinScale
+	config.AdaptiveGarbage.MaxScale = *garbageMaxScale
+	if *garbageHashes != "" {
+		config.Garbage.Hashes = backend.ParseGarbageHashes(*garbageHashes)
+	}
+	if *garbageEncodings != "" {
+		config.Garbage.Encodings = backend.ParseGarbageEncodings(*garbageEncodings)
+	}
+	mode, err := backend.ParseGarbageMode(*garbageMode)
+	if err != nil {
+		log.Fatalf("Error parsing garbage flags: %v\n", err)
+	}
+	config.Garbage.Mode = mode
+	for _, name := range strings.Split(*worlds, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			config.Worlds = append(config.Worlds, backend.WorldConfig{Name: name})
+		}
+	}
+
+	for _, name := range strings.Split(*mods, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			config.Chat.Moderators = append(config.Chat.Moderators, name)
+		}
+	}
+
 	mux := http.NewServeMux()
 
-	wt := backend.NewWebTransportServer()
+	wt := backend.NewWebTransportServer(config)
 	chain := backend.Chain{backend.WithCORS}
 	mux.Handle("/login", chain.ThenFunc(wt.HandleLogin))
+	mux.Handle("/stats/garbage", chain.ThenFunc(wt.HandleGarbageStats))
+	mux.Handle("/stats/load", chain.ThenFunc(wt.HandleLoadStats))
 
 	// Http server
 	server := &http.Server{
@@ -67,7 +148,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	err := server.Shutdown(ctx)
+	err = server.Shutdown(ctx)
 	if err != nil {
 		log.Printf("Error shutting down server: %s\n", err)
 	}
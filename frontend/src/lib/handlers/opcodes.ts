@@ -10,16 +10,33 @@ export enum OpCodes {
 	BConnect,
 	BPlayerMoved,
 	BChat,
+	BPresence,
+	BItem,
+	BRename,
 
 	//Server
 	Server,
 	SGarbage,
 	SGarbageAck,
 	SPlayers,
+	SMoveCorrection,
+	SMoveAck,
+	SNotice,
+	SWorld,
+	SChatHistory,
+	SItems,
+	SInventory,
+	SPathStep,
 
 	// Client
 	Client,
 	CChat,
 	CMoved,
-	CGarbage
+	CGarbage,
+	CChangeWorld,
+	CChatHistory,
+	CMoveTo,
+	CSpectate,
+	CRename,
+	CPlayers
 }
@@ -18,9 +18,11 @@ func main() {
 
 	mux := http.NewServeMux()
 
-	wt := backend.NewWebTransportServer()
+	wt := backend.NewWebTransportServer(backend.DefaultGameConfig())
 	chain := backend.Chain{backend.WithCORS}
 	mux.Handle("/login", chain.ThenFunc(wt.HandleLogin))
+	mux.Handle("/stats/garbage", chain.ThenFunc(wt.HandleGarbageStats))
+	mux.Handle("/stats/load", chain.ThenFunc(wt.HandleLoadStats))
 
 	// Http server
 	server := &http.Server{